@@ -0,0 +1,329 @@
+package go_stockx_client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	http "github.com/bogdanfinn/fhttp"
+)
+
+var ErrCassetteMiss = errors.New("no recorded interaction for request")
+
+type CassetteMode int
+
+const (
+	// CassetteModeRecord sends all requests over the wrapped transport and writes every exchange to the cassette file.
+	CassetteModeRecord CassetteMode = iota
+	// CassetteModeReplay answers all requests from the cassette file without any network access.
+	CassetteModeReplay
+)
+
+const cassetteRedacted = "[REDACTED]"
+
+var defaultRedactedHeaders = []string{"Cookie", "Set-Cookie", "Authorization", "Proxy-Authorization"}
+
+type Cassette struct {
+	Interactions []CassetteInteraction `json:"interactions"`
+}
+
+// CassetteInteraction is one recorded exchange. Body holds the raw response bytes and is base64 encoded
+// in the cassette file, so binary and non UTF-8 responses are replayed unchanged.
+type CassetteInteraction struct {
+	Method         string              `json:"method"`
+	URL            string              `json:"url"`
+	RequestHeader  map[string][]string `json:"requestHeader"`
+	Status         int                 `json:"status"`
+	ResponseHeader map[string][]string `json:"responseHeader"`
+	Body           []byte              `json:"body"`
+	RecordedAt     time.Time           `json:"recordedAt"`
+}
+
+type CassetteOption func(config *cassetteConfig)
+
+type cassetteConfig struct {
+	ignoredQueryParams map[string]bool
+	redactedHeaders    []string
+}
+
+// WithIgnoredQueryParams excludes the given query parameters when matching requests against recorded
+// interactions, for example parameters containing timestamps or the currency.
+func WithIgnoredQueryParams(params ...string) CassetteOption {
+	return func(config *cassetteConfig) {
+		for _, param := range params {
+			config.ignoredQueryParams[param] = true
+		}
+	}
+}
+
+// WithRedactedHeaders adds headers whose values are replaced before an interaction is written.
+// Cookie, Set-Cookie, Authorization and Proxy-Authorization are always redacted.
+func WithRedactedHeaders(headers ...string) CassetteOption {
+	return func(config *cassetteConfig) {
+		config.redactedHeaders = append(config.redactedHeaders, headers...)
+	}
+}
+
+func newCassetteConfig(options []CassetteOption) *cassetteConfig {
+	config := &cassetteConfig{
+		ignoredQueryParams: map[string]bool{},
+		redactedHeaders:    append([]string(nil), defaultRedactedHeaders...),
+	}
+
+	for _, option := range options {
+		option(config)
+	}
+
+	return config
+}
+
+// matchKey identifies a request by method and url, without credentials, ignored query parameters and
+// with the remaining query parameters sorted.
+func (c *cassetteConfig) matchKey(method string, rawUrl string) string {
+	parsedUrl, err := url.Parse(rawUrl)
+	if err != nil {
+		return method + " " + rawUrl
+	}
+
+	parsedUrl.User = nil
+
+	query := parsedUrl.Query()
+	for param := range c.ignoredQueryParams {
+		query.Del(param)
+	}
+
+	parsedUrl.RawQuery = query.Encode()
+
+	return method + " " + parsedUrl.String()
+}
+
+func (c *cassetteConfig) redact(header map[string][]string) map[string][]string {
+	redacted := map[string][]string{}
+
+	for key, values := range header {
+		if key == http.HeaderOrderKey || key == http.PHeaderOrderKey {
+			continue
+		}
+
+		redacted[key] = append([]string(nil), values...)
+
+		for _, redactedHeader := range c.redactedHeaders {
+			if strings.EqualFold(key, redactedHeader) {
+				redacted[key] = []string{cassetteRedacted}
+			}
+		}
+	}
+
+	return redacted
+}
+
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+
+	cassette := &Cassette{}
+	err = json.Unmarshal(data, cassette)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode cassette: %w", err)
+	}
+
+	return cassette, nil
+}
+
+// Save writes the cassette atomically, a crash while recording never leaves a truncated file behind.
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cassette: %w", err)
+	}
+
+	tmpFile, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create cassette: %w", err)
+	}
+
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.Write(data)
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+
+	err = os.Rename(tmpFile.Name(), path)
+	if err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+
+	return nil
+}
+
+type recordingTransport struct {
+	sync.Mutex
+	inner    Transport
+	path     string
+	config   *cassetteConfig
+	cassette *Cassette
+}
+
+// NewRecordingTransport wraps a transport and writes every exchange to a new cassette file at path.
+func NewRecordingTransport(inner Transport, path string, options ...CassetteOption) Transport {
+	return &recordingTransport{
+		inner:    inner,
+		path:     path,
+		config:   newCassetteConfig(options),
+		cassette: &Cassette{},
+	}
+}
+
+func (t *recordingTransport) SetProxy(proxyUrl string) error {
+	return t.inner.SetProxy(proxyUrl)
+}
+
+func (t *recordingTransport) GetProxy() string {
+	return t.inner.GetProxy()
+}
+
+// SetIdentity forwards the identity if the wrapped transport supports it.
+func (t *recordingTransport) SetIdentity(identity BrowserIdentity) error {
+	if identityTransport, ok := t.inner.(IdentityTransport); ok {
+		return identityTransport.SetIdentity(identity)
+	}
+
+	return nil
+}
+
+func (t *recordingTransport) Do(req *http.Request) (*http.Response, error) {
+	resp, err := t.inner.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body for recording: %w", err)
+	}
+
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	recordUrl := *req.URL
+	recordUrl.User = nil
+
+	responseHeader := t.config.redact(resp.Header)
+	delete(responseHeader, "Content-Encoding")
+	delete(responseHeader, "Content-Length")
+
+	t.Lock()
+	defer t.Unlock()
+
+	t.cassette.Interactions = append(t.cassette.Interactions, CassetteInteraction{
+		Method:         req.Method,
+		URL:            recordUrl.String(),
+		RequestHeader:  t.config.redact(req.Header),
+		Status:         resp.StatusCode,
+		ResponseHeader: responseHeader,
+		Body:           body,
+		RecordedAt:     time.Now().UTC(),
+	})
+
+	err = t.cassette.Save(t.path)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+type replayTransport struct {
+	sync.Mutex
+	config       *cassetteConfig
+	interactions map[string][]CassetteInteraction
+	played       map[string]int
+	proxyUrl     string
+}
+
+// NewReplayTransport serves responses from the cassette file at path. Interactions with the same
+// method and url are replayed in recording order, the last one is repeated once all were played.
+func NewReplayTransport(path string, options ...CassetteOption) (Transport, error) {
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &replayTransport{
+		config:       newCassetteConfig(options),
+		interactions: map[string][]CassetteInteraction{},
+		played:       map[string]int{},
+	}
+
+	for _, interaction := range cassette.Interactions {
+		key := t.config.matchKey(interaction.Method, interaction.URL)
+		t.interactions[key] = append(t.interactions[key], interaction)
+	}
+
+	return t, nil
+}
+
+func (t *replayTransport) SetProxy(proxyUrl string) error {
+	t.Lock()
+	defer t.Unlock()
+
+	t.proxyUrl = proxyUrl
+
+	return nil
+}
+
+func (t *replayTransport) GetProxy() string {
+	t.Lock()
+	defer t.Unlock()
+
+	return t.proxyUrl
+}
+
+func (t *replayTransport) Do(req *http.Request) (*http.Response, error) {
+	key := t.config.matchKey(req.Method, req.URL.String())
+
+	t.Lock()
+	interactions := t.interactions[key]
+	if len(interactions) == 0 {
+		t.Unlock()
+		return nil, fmt.Errorf("%w: %s", ErrCassetteMiss, key)
+	}
+
+	index := t.played[key]
+	if index >= len(interactions) {
+		index = len(interactions) - 1
+	}
+
+	t.played[key] = index + 1
+	t.Unlock()
+
+	interaction := interactions[index]
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.Status, http.StatusText(interaction.Status)),
+		StatusCode:    interaction.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header(interaction.ResponseHeader),
+		Body:          ioutil.NopCloser(bytes.NewReader(interaction.Body)),
+		ContentLength: int64(len(interaction.Body)),
+		Request:       req,
+	}, nil
+}
@@ -0,0 +1,135 @@
+package go_stockx_client_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	http "github.com/bogdanfinn/fhttp"
+	go_stockx_client "github.com/bogdanfinn/go-stockx-client"
+	"github.com/bogdanfinn/go-stockx-client/stockxtest"
+)
+
+// shippedCassette was recorded against stockxtest with the server url replaced by https://stockx.com.
+var shippedCassette = filepath.Join("testdata", "cassettes", "dunk-low.json")
+
+func TestCassetteReplaysShippedCassette(t *testing.T) {
+	client, err := go_stockx_client.NewClient("EUR", "DE", go_stockx_client.NewNoopLogger(), false,
+		go_stockx_client.WithCassette(go_stockx_client.CassetteModeReplay, shippedCassette),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	results, err := client.SearchProducts("dunk low", 5)
+	if err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+
+	if len(results) != 1 || results[0].ProductIdentifier != stockxtest.DefaultProductURLKey {
+		t.Fatalf("unexpected search results: %+v", results)
+	}
+
+	product, err := client.GetProduct(stockxtest.DefaultProductURLKey)
+	if err != nil {
+		t.Fatalf("product details failed: %v", err)
+	}
+
+	if product.UUID != stockxtest.DefaultProductUUID || len(product.Variants) != 2 {
+		t.Fatalf("unexpected product: %+v", product)
+	}
+
+	_, err = client.GetProduct("some-other-product")
+	if err == nil {
+		t.Fatalf("expected a cassette miss for requests which were not recorded")
+	}
+}
+
+func TestCassetteRecordAndReplay(t *testing.T) {
+	server := stockxtest.NewServer()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	recording := newTestClient(t, server, go_stockx_client.WithCassette(go_stockx_client.CassetteModeRecord, path))
+
+	recorded, err := recording.GetProduct(stockxtest.DefaultProductURLKey)
+	if err != nil {
+		t.Fatalf("product details failed while recording: %v", err)
+	}
+
+	// replay has to work without the server
+	server.Close()
+
+	replaying, err := go_stockx_client.NewClient("EUR", "DE", go_stockx_client.NewNoopLogger(), false,
+		go_stockx_client.WithBaseURL(server.URL),
+		go_stockx_client.WithCassette(go_stockx_client.CassetteModeReplay, path),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	replayed, err := replaying.GetProduct(stockxtest.DefaultProductURLKey)
+	if err != nil {
+		t.Fatalf("product details failed while replaying: %v", err)
+	}
+
+	if replayed.UUID != recorded.UUID || replayed.Lowestask != recorded.Lowestask || len(replayed.Variants) != len(recorded.Variants) {
+		t.Fatalf("replayed product %+v differs from recorded %+v", replayed, recorded)
+	}
+
+	cassette, err := go_stockx_client.LoadCassette(path)
+	if err != nil {
+		t.Fatalf("failed to load cassette: %v", err)
+	}
+
+	for _, interaction := range cassette.Interactions {
+		for _, cookie := range interaction.RequestHeader["Cookie"] {
+			if cookie != "[REDACTED]" {
+				t.Fatalf("expected cookies to be redacted, got %q", cookie)
+			}
+		}
+	}
+}
+
+func TestCassetteKeepsBinaryBodies(t *testing.T) {
+	body := []byte{0xff, 0xfe, 'o', 'k', 0x00, 0xc3}
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	cassette := &go_stockx_client.Cassette{Interactions: []go_stockx_client.CassetteInteraction{
+		{Method: "GET", URL: "https://stockx.com/api/binary", Status: 200, Body: body},
+	}}
+
+	if err := cassette.Save(path); err != nil {
+		t.Fatalf("failed to save cassette: %v", err)
+	}
+
+	transport, err := go_stockx_client.NewReplayTransport(path)
+	if err != nil {
+		t.Fatalf("failed to load cassette: %v", err)
+	}
+
+	req, err := newGetRequest("https://stockx.com/api/binary")
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+
+	resp, err := transport.Do(req)
+	if err != nil {
+		t.Fatalf("replay failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	replayed, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read replayed body: %v", err)
+	}
+
+	if !bytes.Equal(replayed, body) {
+		t.Fatalf("expected body %v, got %v", body, replayed)
+	}
+}
+
+func newGetRequest(url string) (*http.Request, error) {
+	return http.NewRequest(http.MethodGet, url, nil)
+}
@@ -0,0 +1,107 @@
+package go_stockx_client
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	http "github.com/bogdanfinn/fhttp"
+)
+
+type ChallengeKind string
+
+const (
+	ChallengePerimeterX ChallengeKind = "perimeterx"
+	ChallengeCloudflare ChallengeKind = "cloudflare"
+	// ChallengeUnknown is a blocked response (403 with an html page) without markers of a known vendor.
+	ChallengeUnknown ChallengeKind = "unknown"
+)
+
+const defaultChallengeRetries = 2
+
+// ChallengeError is returned when stockx answers with a bot protection challenge instead of the requested data.
+type ChallengeError struct {
+	Kind       ChallengeKind
+	StatusCode int
+	URL        string
+	Header     http.Header
+	Body       []byte
+}
+
+func (e *ChallengeError) Error() string {
+	return fmt.Sprintf("stockx served a %s challenge for %s (status code %d)", e.Kind, e.URL, e.StatusCode)
+}
+
+// Is makes errors.Is(err, ErrChallenge) true for every ChallengeError.
+func (e *ChallengeError) Is(target error) bool {
+	return target == ErrChallenge
+}
+
+// ChallengeHandler is called whenever a request is answered with a challenge. It can solve the challenge,
+// switch the proxy of the client or wait, and decides whether the request is sent again.
+// attempt starts at 1 and is increased for every retry of the same request.
+type ChallengeHandler interface {
+	HandleChallenge(client Client, challenge *ChallengeError, attempt int) (retry bool, err error)
+}
+
+type ChallengeHandlerFunc func(client Client, challenge *ChallengeError, attempt int) (bool, error)
+
+func (f ChallengeHandlerFunc) HandleChallenge(client Client, challenge *ChallengeError, attempt int) (bool, error) {
+	return f(client, challenge, attempt)
+}
+
+var perimeterXStrongMarkers = []string{`id="px-captcha"`, "captcha.px-cdn.net"}
+var perimeterXMarkers = []string{"px-captcha", "_pxAppId", "px-cdn.net", `"blockScript"`, "/captcha/captcha.js"}
+var cloudflareStrongMarkers = []string{"cf_chl_opt", "/cdn-cgi/challenge-platform/"}
+var cloudflareMarkers = []string{"cf-chl", "challenge-platform", "Just a moment...", "Attention Required! | Cloudflare", "cf-browser-verification"}
+
+// DetectChallenge reports whether a response is a PerimeterX or Cloudflare challenge. Successful responses are
+// only considered a challenge if they contain the challenge widget itself, since regular stockx pages
+// embed the PerimeterX sensor script as well.
+func DetectChallenge(statusCode int, header http.Header, body []byte) (ChallengeKind, bool) {
+	if strings.EqualFold(header.Get("cf-mitigated"), "challenge") {
+		return ChallengeCloudflare, true
+	}
+
+	if statusCode >= 200 && statusCode < 300 {
+		switch {
+		case containsAny(body, perimeterXStrongMarkers):
+			return ChallengePerimeterX, true
+		case containsAny(body, cloudflareStrongMarkers):
+			return ChallengeCloudflare, true
+		}
+
+		return "", false
+	}
+
+	if statusCode != http.StatusForbidden && statusCode != http.StatusTooManyRequests && statusCode != http.StatusServiceUnavailable {
+		return "", false
+	}
+
+	switch {
+	case containsAny(body, perimeterXMarkers):
+		return ChallengePerimeterX, true
+	case containsAny(body, cloudflareMarkers):
+		return ChallengeCloudflare, true
+	case strings.EqualFold(header.Get("server"), "cloudflare") && isHtml(header):
+		return ChallengeCloudflare, true
+	case statusCode == http.StatusForbidden && isHtml(header):
+		return ChallengeUnknown, true
+	}
+
+	return "", false
+}
+
+func containsAny(body []byte, markers []string) bool {
+	for _, marker := range markers {
+		if bytes.Contains(body, []byte(marker)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isHtml(header http.Header) bool {
+	return strings.Contains(strings.ToLower(header.Get("content-type")), "text/html")
+}
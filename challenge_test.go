@@ -0,0 +1,194 @@
+package go_stockx_client_test
+
+import (
+	"errors"
+	"testing"
+
+	http "github.com/bogdanfinn/fhttp"
+	go_stockx_client "github.com/bogdanfinn/go-stockx-client"
+	"github.com/bogdanfinn/go-stockx-client/stockxtest"
+)
+
+func TestChallengeRetryUsesRotatedIdentity(t *testing.T) {
+	server := stockxtest.NewServer()
+	defer server.Close()
+
+	var challenged, rotated go_stockx_client.BrowserIdentity
+
+	rotate := go_stockx_client.ChallengeHandlerFunc(func(client go_stockx_client.Client, challenge *go_stockx_client.ChallengeError, attempt int) (bool, error) {
+		var err error
+
+		challenged = client.GetIdentity()
+		rotated, err = client.RotateIdentity()
+
+		return err == nil, err
+	})
+
+	client := newTestClient(t, server,
+		go_stockx_client.WithIdentities(go_stockx_client.IdentityFirefox117Windows, go_stockx_client.IdentityChrome117MacOS),
+		go_stockx_client.WithChallengeHandler(rotate, 1),
+	)
+
+	server.Fail(stockxtest.Captcha().On("/api/products/"))
+
+	product, err := client.GetProduct(stockxtest.DefaultProductURLKey)
+	if err != nil {
+		t.Fatalf("expected the retry to succeed, got %v", err)
+	}
+
+	if product.UUID != stockxtest.DefaultProductUUID {
+		t.Fatalf("unexpected product %s", product.UUID)
+	}
+
+	requests := server.RequestsTo("/api/products/")
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 product requests, got %d", len(requests))
+	}
+
+	if challenged.Name == rotated.Name {
+		t.Fatalf("expected the identity to change, stayed %s", rotated.Name)
+	}
+
+	if userAgent := requests[0].Header.Get("User-Agent"); userAgent != challenged.UserAgent {
+		t.Fatalf("expected the first attempt to use the user agent of %s, got %q", challenged.Name, userAgent)
+	}
+
+	if userAgent := requests[1].Header.Get("User-Agent"); userAgent != rotated.UserAgent {
+		t.Fatalf("expected the retry to use the rotated user agent, got %q", userAgent)
+	}
+
+	var homepage []stockxtest.Request
+	for _, request := range server.Requests() {
+		if request.Path == "/" {
+			homepage = append(homepage, request)
+		}
+	}
+
+	if len(homepage) != 2 {
+		t.Fatalf("expected the rotated session to be initialized again, got %d homepage requests", len(homepage))
+	}
+
+	if userAgent := homepage[1].Header.Get("User-Agent"); userAgent != rotated.UserAgent {
+		t.Fatalf("expected the new session to be initialized with the rotated user agent, got %q", userAgent)
+	}
+}
+
+func TestDetectChallenge(t *testing.T) {
+	html := http.Header{"Content-Type": {"text/html; charset=utf-8"}}
+	json := http.Header{"Content-Type": {"application/json"}}
+
+	tests := []struct {
+		name       string
+		statusCode int
+		header     http.Header
+		body       string
+		kind       go_stockx_client.ChallengeKind
+		challenge  bool
+	}{
+		{
+			name:       "perimeterx captcha",
+			statusCode: http.StatusForbidden,
+			header:     html,
+			body:       `<div id="px-captcha"></div><script>window._pxAppId = 'PX16uD0kOF';</script>`,
+			kind:       go_stockx_client.ChallengePerimeterX,
+			challenge:  true,
+		},
+		{
+			name:       "cloudflare mitigated header",
+			statusCode: http.StatusForbidden,
+			header:     http.Header{"Server": {"cloudflare"}, "Cf-Mitigated": {"challenge"}, "Content-Type": {"text/html; charset=UTF-8"}},
+			body:       `<title>Just a moment...</title>`,
+			kind:       go_stockx_client.ChallengeCloudflare,
+			challenge:  true,
+		},
+		{
+			name:       "cloudflare page",
+			statusCode: http.StatusServiceUnavailable,
+			header:     html,
+			body:       `<title>Just a moment...</title><script src="/cdn-cgi/challenge-platform/h/g/orchestrate/chl_page/v1"></script>`,
+			kind:       go_stockx_client.ChallengeCloudflare,
+			challenge:  true,
+		},
+		{
+			name:       "cloudflare server without markers",
+			statusCode: http.StatusTooManyRequests,
+			header:     http.Header{"Server": {"cloudflare"}, "Content-Type": {"text/html"}},
+			body:       `<html><body>blocked</body></html>`,
+			kind:       go_stockx_client.ChallengeCloudflare,
+			challenge:  true,
+		},
+		{
+			name:       "403 html without markers",
+			statusCode: http.StatusForbidden,
+			header:     html,
+			body:       `<html><body>Forbidden</body></html>`,
+			kind:       go_stockx_client.ChallengeUnknown,
+			challenge:  true,
+		},
+		{
+			name:       "403 json without markers",
+			statusCode: http.StatusForbidden,
+			header:     json,
+			body:       `{"error": "Forbidden"}`,
+		},
+		{
+			name:       "429 without markers",
+			statusCode: http.StatusTooManyRequests,
+			header:     html,
+			body:       `<html><body>Too Many Requests</body></html>`,
+		},
+		{
+			name:       "404 with challenge markers",
+			statusCode: http.StatusNotFound,
+			header:     html,
+			body:       `<div id="px-captcha"></div>`,
+		},
+		{
+			name:       "200 with the perimeterx sensor script",
+			statusCode: http.StatusOK,
+			header:     html,
+			body:       `<script>window._pxAppId = 'PX16uD0kOF';</script><script src="https://client.px-cdn.net/PX16uD0kOF/main.min.js"></script>`,
+		},
+		{
+			name:       "200 json",
+			statusCode: http.StatusOK,
+			header:     json,
+			body:       `{"Product": {"title": "Just a moment..."}}`,
+		},
+		{
+			name:       "200 with the captcha widget",
+			statusCode: http.StatusOK,
+			header:     html,
+			body:       `<div id="px-captcha"></div>`,
+			kind:       go_stockx_client.ChallengePerimeterX,
+			challenge:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			kind, challenge := go_stockx_client.DetectChallenge(test.statusCode, test.header, []byte(test.body))
+			if challenge != test.challenge || kind != test.kind {
+				t.Fatalf("expected (%q, %t), got (%q, %t)", test.kind, test.challenge, kind, challenge)
+			}
+		})
+	}
+}
+
+func TestCloudflareChallengeError(t *testing.T) {
+	server := stockxtest.NewServer()
+	defer server.Close()
+
+	server.Fail(stockxtest.Cloudflare().On("/api/products/"))
+
+	_, err := newTestClient(t, server).GetProduct(stockxtest.DefaultProductURLKey)
+
+	var challenge *go_stockx_client.ChallengeError
+	if !errors.As(err, &challenge) {
+		t.Fatalf("expected a ChallengeError, got %v", err)
+	}
+
+	if challenge.Kind != go_stockx_client.ChallengeCloudflare || challenge.StatusCode != http.StatusForbidden || challenge.Header.Get("cf-mitigated") != "challenge" || len(challenge.Body) == 0 {
+		t.Fatalf("unexpected challenge: %+v", challenge)
+	}
+}
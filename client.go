@@ -1,73 +1,94 @@
 package go_stockx_client
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"github.com/bogdanfinn/tls-client/profiles"
 	"io/ioutil"
+	"math/rand"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	http "github.com/bogdanfinn/fhttp"
-	"github.com/bogdanfinn/fhttp/cookiejar"
-	tls_client "github.com/bogdanfinn/tls-client"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const stockxBaseUrl = "https://stockx.com/"
-const stockxSearchEndpointTemplate = "https://stockx.com/api/browse?_search=%s&page=1&resultsPerPage=%d&dataType=product&facetsToRetrieve[]=browseVerticals&propsToRetrieve[][]=brand&propsToRetrieve[][]=colorway&propsToRetrieve[][]=media.thumbUrl&propsToRetrieve[][]=title&propsToRetrieve[][]=productCategory&propsToRetrieve[][]=shortDescription&propsToRetrieve[][]=urlKey"
-const stockxProductDetailsEndpointTemplate = "https://stockx.com/api/products/%s?includes=market&currency=%s&country=%s&market=%s"
-
-var stockxHeader = http.Header{
-	"accept":             {"application/json"},
-	"accept-language":    {"de-DE,de;q=0.9,en-US;q=0.8,en;q=0.7"},
-	"app-platform":       {"Iron"},
-	"app-version":        {"2022.07.17.01"},
-	"cache-control":      {"no-cache"},
-	"pragma":             {"no-cache"},
-	"referer":            {"https://stockx.com/de-de"},
-	"sec-ch-ua":          {`".Not/A)Brand";v="99", "Google Chrome";v="103", "Chromium";v="103"`},
-	"sec-ch-ua-mobile":   {"?0"},
-	"sec-ch-ua-platform": {`"macOS"`},
-	"sec-fetch-dest":     {"empty"},
-	"sec-fetch-mode":     {"cors"},
-	"sec-fetch-site":     {"same-origin"},
-	"user-agent":         {"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/103.0.0.0 Safari/537.36"},
-	"x-requested-with":   {"XMLHttpRequest"},
-	http.HeaderOrderKey: {
-		"accept",
-		"accept-language",
-		"app-platform",
-		"app-version",
-		"cache-control",
-		"pragma",
-		"referer",
-		"sec-ch-ua",
-		"sec-ch-ua-mobile",
-		"sec-ch-ua-platform",
-		"sec-fetch-dest",
-		"sec-fetch-mode",
-		"sec-fetch-site",
-		"user-agent",
-		"x-requested-with",
-	},
-}
+const stockxSearchEndpointTemplate = "api/browse?_search=%s&page=1&resultsPerPage=%d&dataType=product&facetsToRetrieve[]=browseVerticals&propsToRetrieve[][]=brand&propsToRetrieve[][]=colorway&propsToRetrieve[][]=media.thumbUrl&propsToRetrieve[][]=title&propsToRetrieve[][]=productCategory&propsToRetrieve[][]=shortDescription&propsToRetrieve[][]=urlKey"
+const stockxProductDetailsEndpointTemplate = "api/products/%s?includes=market&currency=%s&country=%s&market=%s"
+const stockxProductActivityEndpointTemplate = "api/products/%s/activity?state=%d&currency=%s&country=%s&limit=%d&page=%d&sort=createdAt&order=DESC"
+const stockxRelatedProductsEndpointTemplate = "api/products/%s/related?currency=%s&country=%s&limit=%d"
+const stockxProductChartEndpointTemplate = "api/products/%s/chart?start_date=%s&end_date=%s&intervals=%d&format=highstock&currency=%s&country=%s"
+
+const stockxStyleIDSearchLimit = 10
+const stockxActivityPageSize = 20
+const stockxChartDefaultPoints = 100
+const stockxChartDateLayout = "2006-01-02"
+const stockxOrderBookPageSize = 100
+const stockxOrderBookMaxPages = 10
+
+// DefaultRelatedProductsLimit is the number of related products GetRelatedProducts returns for a limit of 0 or less.
+const DefaultRelatedProductsLimit = 10
+
+const (
+	activityStateBids  = 300
+	activityStateAsks  = 400
+	activityStateSales = 480
+)
 
 type Client interface {
 	SearchProducts(query string, limit int) ([]SearchResultProduct, error)
+	SearchProductsResult(query string, limit int) (*Result[[]SearchResultProduct], error)
 	GetProduct(productIdentifier string) (*ProductDetails, error)
+	GetProductResult(productIdentifier string) (*Result[*ProductDetails], error)
+	GetProductByStyleID(styleID string) (*ProductDetails, error)
+	GetProductByGTIN(gtin string) (*ProductDetails, *ProductDetailsVariant, error)
+	GetRelatedProducts(productIdentifier string, limit int) ([]SearchResultProduct, error)
+	GetSales(productIdentifier string, variantUUID string, page int) ([]Sale, error)
+	GetOrderBook(productIdentifier string, variantUUID string) (*OrderBook, error)
+	GetPriceHistory(productIdentifier string, numberOfPoints int, start time.Time, end time.Time, variantUUID string) ([]PricePoint, error)
 	SetProxy(proxyUrl string) error
 	GetProxy() string
+	RotateIdentity() (BrowserIdentity, error)
+	GetIdentity() BrowserIdentity
+	WithContext(ctx context.Context) Client
 }
 
+// client holds the configuration of a client, the mutable session state is shared through session so that
+// WithContext can return a cheap copy bound to another context.
 type client struct {
-	initialized bool
-	logger      Logger
-	currency    string
-	locale      string
-	httpClient  tls_client.HttpClient
-	vatAccount  bool
+	ctx        context.Context
+	session    *clientSession
+	logger     Logger
+	currency   string
+	locale     string
+	transport  Transport
+	vatAccount bool
+	schemaMode SchemaMode
+	baseUrl    string
+	metrics    Metrics
+	tracer     trace.Tracer
+
+	challengeHandler ChallengeHandler
+	challengeRetries int
+
+	identities []BrowserIdentity
+	language   string
+
+	headerBuilders []HeaderBuilder
+}
+
+type clientSession struct {
+	sync.Mutex
+	initialized   bool
+	identityIndex int
+	header        http.Header
 }
 
 var clientContainer = struct {
@@ -75,7 +96,7 @@ var clientContainer = struct {
 	instance Client
 }{}
 
-func ProvideClient(currency string, locale string, logger Logger, vatAccount bool) (Client, error) {
+func ProvideClient(currency string, locale string, logger Logger, vatAccount bool, options ...ClientOption) (Client, error) {
 	clientContainer.Lock()
 	defer clientContainer.Unlock()
 
@@ -83,7 +104,7 @@ func ProvideClient(currency string, locale string, logger Logger, vatAccount boo
 		return clientContainer.instance, nil
 	}
 
-	instance, err := NewClient(currency, locale, logger, vatAccount)
+	instance, err := NewClient(currency, locale, logger, vatAccount, options...)
 
 	if err != nil {
 		return nil, err
@@ -94,45 +115,122 @@ func ProvideClient(currency string, locale string, logger Logger, vatAccount boo
 	return clientContainer.instance, nil
 }
 
-func NewClient(currency string, locale string, logger Logger, vatAccount bool) (Client, error) {
-	jar, _ := cookiejar.New(nil)
+func NewClient(currency string, locale string, logger Logger, vatAccount bool, options ...ClientOption) (Client, error) {
+	config := &clientConfig{
+		schemaMode:     SchemaModeOff,
+		baseUrl:        stockxBaseUrl,
+		metrics:        noopMetrics{},
+		tracerProvider: trace.NewNoopTracerProvider(),
+	}
 
-	options := []tls_client.HttpClientOption{
-		tls_client.WithTimeoutSeconds(30),
-		tls_client.WithClientProfile(profiles.Chrome_117),
-		tls_client.WithCookieJar(jar),
-		// tls_client.WithNotFollowRedirects(),
+	for _, option := range options {
+		option(config)
 	}
 
-	httpClient, err := tls_client.NewHttpClient(logger, options...)
+	identities := config.identities
+	if len(identities) == 0 {
+		identities = []BrowserIdentity{DefaultIdentity}
+	}
 
+	for _, identity := range identities {
+		err := identity.Validate()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	identityIndex := 0
+	if config.randomIdentity {
+		identityIndex = rand.Intn(len(identities))
+	}
+
+	transport, err := buildTransport(logger, config, identities[identityIndex])
 	if err != nil {
-		return nil, fmt.Errorf("failed to construct http client: %w", err)
+		return nil, err
 	}
 
-	return &client{
-		initialized: false,
-		logger:      logger,
-		currency:    strings.ToUpper(currency),
-		locale:      strings.ToUpper(locale),
-		httpClient:  httpClient,
-		vatAccount:  vatAccount,
-	}, nil
+	language := config.language
+	if language == "" {
+		language = LanguageForCountry(locale)
+	}
+
+	c := &client{
+		ctx: context.Background(),
+		session: &clientSession{
+			initialized:   false,
+			identityIndex: identityIndex,
+		},
+		logger:     logger,
+		currency:   strings.ToUpper(currency),
+		locale:     strings.ToUpper(locale),
+		transport:  transport,
+		vatAccount: vatAccount,
+		schemaMode: config.schemaMode,
+		baseUrl:    config.baseUrl,
+		metrics:    config.metrics,
+		tracer:     config.tracerProvider.Tracer(tracerName),
+
+		challengeHandler: config.challengeHandler,
+		challengeRetries: config.challengeRetries,
+
+		identities: identities,
+		language:   language,
+
+		headerBuilders: config.headerBuilders,
+	}
+
+	c.session.header = c.identityHeader(identities[identityIndex])
+
+	return c, nil
+}
+
+func buildTransport(logger Logger, config *clientConfig, identity BrowserIdentity) (Transport, error) {
+	if config.cassettePath != "" && config.cassetteMode == CassetteModeReplay {
+		return NewReplayTransport(config.cassettePath, config.cassetteOptions...)
+	}
+
+	transport := config.transport
+	if transport == nil {
+		tlsClientTransport, err := newTLSClientTransport(logger, identity.ClientProfile)
+		if err != nil {
+			return nil, err
+		}
+
+		transport = tlsClientTransport
+	} else if identityTransport, ok := transport.(IdentityTransport); ok {
+		err := identityTransport.SetIdentity(identity)
+		if err != nil {
+			return nil, fmt.Errorf("failed to set browser identity: %w", err)
+		}
+	}
+
+	if config.cassettePath != "" {
+		transport = NewRecordingTransport(transport, config.cassettePath, config.cassetteOptions...)
+	}
+
+	return transport, nil
 }
 
 func (c *client) initialize() error {
-	if c.initialized {
+	c.session.Lock()
+	initialized := c.session.initialized
+	c.session.Unlock()
+
+	if initialized {
 		return nil
 	}
 
-	statusCode, _, err := c.doRequest(stockxBaseUrl, stockxHeader)
+	statusCode, _, err := c.doRequest("homepage", c.baseUrl)
 
 	if err != nil {
 		return fmt.Errorf("failed to initialize client: %w", err)
 	}
 
 	if statusCode == http.StatusOK {
-		c.initialized = true
+		c.session.Lock()
+		c.session.initialized = true
+		c.session.Unlock()
+
 		return nil
 	}
 
@@ -140,14 +238,82 @@ func (c *client) initialize() error {
 }
 
 func (c *client) SetProxy(proxyUrl string) error {
-	return c.httpClient.SetProxy(proxyUrl)
+	return c.transport.SetProxy(proxyUrl)
 }
 
 func (c *client) GetProxy() string {
-	return c.httpClient.GetProxy()
+	return c.transport.GetProxy()
+}
+
+// RotateIdentity switches to the next browser identity configured with WithIdentities and starts a new session.
+// With a single identity the session is restarted with the same identity.
+func (c *client) RotateIdentity() (BrowserIdentity, error) {
+	c.session.Lock()
+	defer c.session.Unlock()
+
+	index := (c.session.identityIndex + 1) % len(c.identities)
+	identity := c.identities[index]
+
+	if identityTransport, ok := c.transport.(IdentityTransport); ok {
+		err := identityTransport.SetIdentity(identity)
+		if err != nil {
+			return c.identities[c.session.identityIndex], fmt.Errorf("failed to set browser identity: %w", err)
+		}
+	}
+
+	c.session.identityIndex = index
+	c.session.header = c.identityHeader(identity)
+	c.session.initialized = false
+
+	c.logger.Info("rotated browser identity to %s", identity.Name)
+
+	return identity, nil
 }
 
-func (c *client) SearchProducts(query string, limit int) ([]SearchResultProduct, error) {
+func (c *client) GetIdentity() BrowserIdentity {
+	c.session.Lock()
+	defer c.session.Unlock()
+
+	return c.identities[c.session.identityIndex]
+}
+
+// identityHeader returns the headers of identity localized for the language of the client.
+func (c *client) identityHeader(identity BrowserIdentity) http.Header {
+	return identity.header(c.language, refererUrl(c.baseUrl, c.language))
+}
+
+// requestHeader returns the headers of the current identity. The map is shared, doSingleRequest sends a copy of it.
+func (c *client) requestHeader() http.Header {
+	c.session.Lock()
+	defer c.session.Unlock()
+
+	return c.session.header
+}
+
+func (c *client) SearchProducts(query string, limit int) (products []SearchResultProduct, err error) {
+	defer c.observeOperation("SearchProducts", time.Now(), &err)
+
+	c, span := c.startSpan("stockx.SearchProducts", trace.SpanKindInternal, attribute.String("stockx.query", query), attribute.Int("stockx.limit", limit))
+	defer func() { endSpan(span, err) }()
+
+	result, err := c.searchProducts(query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Value, nil
+}
+
+func (c *client) SearchProductsResult(query string, limit int) (result *Result[[]SearchResultProduct], err error) {
+	defer c.observeOperation("SearchProductsResult", time.Now(), &err)
+
+	c, span := c.startSpan("stockx.SearchProducts", trace.SpanKindInternal, attribute.String("stockx.query", query), attribute.Int("stockx.limit", limit))
+	defer func() { endSpan(span, err) }()
+
+	return c.searchProducts(query, limit)
+}
+
+func (c *client) searchProducts(query string, limit int) (*Result[[]SearchResultProduct], error) {
 	err := c.initialize()
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize client: %w", err)
@@ -159,69 +325,505 @@ func (c *client) SearchProducts(query string, limit int) ([]SearchResultProduct,
 		preparedQuery = strings.Join(queryParts, "+")
 	}
 
-	searchUrl := fmt.Sprintf(stockxSearchEndpointTemplate, preparedQuery, limit)
+	searchUrl := c.endpoint(stockxSearchEndpointTemplate, preparedQuery, limit)
 
-	_, respBodyBytes, err := c.doRequest(searchUrl, stockxHeader)
+	raw, err := c.doRawRequest("search", searchUrl)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return newResult[[]SearchResultProduct](raw), fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	result := newResult[[]SearchResultProduct](raw)
+
+	if raw.statusCode != http.StatusOK {
+		return result, fmt.Errorf("received wrong status code during search request: %d", raw.statusCode)
 	}
 
 	response := ProductSearchResultResponse{}
-	err = json.Unmarshal(respBodyBytes, &response)
+	result.SchemaReport, err = c.decodeResponse("search", raw.body, &response)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to convert response json into response struct: %w", err)
+		return result, err
+	}
+
+	result.Value = parseSearchResults(response.Products)
+
+	return result, nil
+}
+
+func (c *client) GetProduct(productIdentifier string) (productDetails *ProductDetails, err error) {
+	defer c.observeOperation("GetProduct", time.Now(), &err)
+
+	c, span := c.startSpan("stockx.GetProduct", trace.SpanKindInternal, attribute.String("stockx.product_identifier", productIdentifier))
+	defer func() { endSpan(span, err) }()
+
+	result, err := c.getProduct(productIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Value, nil
+}
+
+func (c *client) GetProductResult(productIdentifier string) (result *Result[*ProductDetails], err error) {
+	defer c.observeOperation("GetProductResult", time.Now(), &err)
+
+	c, span := c.startSpan("stockx.GetProduct", trace.SpanKindInternal, attribute.String("stockx.product_identifier", productIdentifier))
+	defer func() { endSpan(span, err) }()
+
+	return c.getProduct(productIdentifier)
+}
+
+func (c *client) getProduct(productIdentifier string) (*Result[*ProductDetails], error) {
+	response, raw, err := c.getProductResponse(productIdentifier)
+
+	result := newResult[*ProductDetails](raw)
+	if err != nil {
+		return result, err
 	}
 
-	searchResultProducts := parseSearchResults(response)
+	result.Value = parseProduct(*response)
 
-	return searchResultProducts, nil
+	return result, nil
 }
 
-func (c *client) GetProduct(productIdentifier string) (*ProductDetails, error) {
+func (c *client) getProductResponse(productIdentifier string) (*ProductResponse, *rawResponse, error) {
+	ref, err := ParseProductRef(productIdentifier)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	response, raw, err := c.fetchProductResponse(ref.Identifier)
+	if err != nil {
+		return nil, raw, err
+	}
+
+	// a variant uuid returns the variant itself, the caller wants the product with all its variants
+	if response.Product.Parentuuid != "" && len(response.Product.Children) == 0 {
+		return c.fetchProductResponse(string(response.Product.Parentuuid))
+	}
+
+	return response, raw, nil
+}
+
+func (c *client) fetchProductResponse(productIdentifier string) (*ProductResponse, *rawResponse, error) {
 	err := c.initialize()
 	if err != nil {
-		return nil, fmt.Errorf("failed to initialize client: %w", err)
+		return nil, nil, fmt.Errorf("failed to initialize client: %w", err)
 	}
 
-	productUrl := fmt.Sprintf(stockxProductDetailsEndpointTemplate, productIdentifier, c.currency, c.locale, c.locale)
+	productUrl := c.endpoint(stockxProductDetailsEndpointTemplate, productIdentifier, c.currency, c.locale, c.locale)
 	if c.vatAccount {
-		productUrl = fmt.Sprintf(stockxProductDetailsEndpointTemplate, productIdentifier, c.currency, c.locale, fmt.Sprintf("%s.vat-registered", c.locale))
+		productUrl = c.endpoint(stockxProductDetailsEndpointTemplate, productIdentifier, c.currency, c.locale, fmt.Sprintf("%s.vat-registered", c.locale))
+	}
+	raw, err := c.doRawRequest("product details", productUrl)
+
+	if err != nil {
+		return nil, raw, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if raw.statusCode == http.StatusNotFound {
+		return nil, raw, fmt.Errorf("%w: %s", ErrNotFound, productIdentifier)
+	}
+
+	if raw.statusCode != http.StatusOK {
+		return nil, raw, fmt.Errorf("received wrong status code during product details request: %d", raw.statusCode)
+	}
+
+	response := ProductResponse{}
+	raw.schemaReport, err = c.decodeResponse("product details", raw.body, &response)
+
+	if err != nil {
+		return nil, raw, err
+	}
+
+	return &response, raw, nil
+}
+
+func (c *client) GetProductByGTIN(gtin string) (productDetails *ProductDetails, variant *ProductDetailsVariant, err error) {
+	defer c.observeOperation("GetProductByGTIN", time.Now(), &err)
+
+	wanted := NormalizeGTIN(gtin)
+	if wanted == "" {
+		return nil, nil, fmt.Errorf("%w: invalid gtin %q", ErrNotFound, gtin)
+	}
+
+	searchResults, err := c.searchProducts(strings.TrimSpace(gtin), stockxStyleIDSearchLimit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to search for gtin %s: %w", gtin, err)
+	}
+
+	for _, searchResult := range searchResults.Value {
+		response, _, err := c.getProductResponse(searchResult.ProductIdentifier)
+		if errors.Is(err, ErrNotFound) {
+			c.logger.Warn("skipping search result %s for gtin %s: %s", searchResult.ProductIdentifier, gtin, err.Error())
+			continue
+		}
+
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load product details for %s: %w", searchResult.ProductIdentifier, err)
+		}
+
+		product := parseProduct(*response)
+
+		for i, productVariant := range product.Variants {
+			for _, variantGtin := range productVariant.GTINs {
+				if NormalizeGTIN(variantGtin) == wanted {
+					return product, &product.Variants[i], nil
+				}
+			}
+		}
+	}
+
+	return nil, nil, fmt.Errorf("%w: no product variant with gtin %s", ErrNotFound, gtin)
+}
+
+func (c *client) GetProductByStyleID(styleID string) (productDetails *ProductDetails, err error) {
+	defer c.observeOperation("GetProductByStyleID", time.Now(), &err)
+
+	wanted := NormalizeStyleID(styleID)
+	if wanted == "" {
+		return nil, fmt.Errorf("%w: empty style id", ErrNotFound)
+	}
+
+	searchResults, err := c.searchProducts(styleID, stockxStyleIDSearchLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for style id %s: %w", styleID, err)
+	}
+
+	var matches []*ProductDetails
+
+	for _, searchResult := range searchResults.Value {
+		result, err := c.getProduct(searchResult.ProductIdentifier)
+		if errors.Is(err, ErrNotFound) {
+			// search results can point to products which were removed or renamed since
+			c.logger.Warn("skipping search result %s for style id %s: %s", searchResult.ProductIdentifier, styleID, err.Error())
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to load product details for %s: %w", searchResult.ProductIdentifier, err)
+		}
+
+		if MatchesStyleID(result.Value.Styleid, styleID) {
+			matches = append(matches, result.Value)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("%w: no product with style id %s", ErrNotFound, styleID)
+	case 1:
+		return matches[0], nil
+	default:
+		var identifiers []string
+		for _, match := range matches {
+			identifiers = append(identifiers, match.ProductIdentifier)
+		}
+
+		return nil, fmt.Errorf("%w: style id %s matches %s", ErrAmbiguous, styleID, strings.Join(identifiers, ", "))
+	}
+}
+
+func (c *client) GetRelatedProducts(productIdentifier string, limit int) (products []SearchResultProduct, err error) {
+	defer c.observeOperation("GetRelatedProducts", time.Now(), &err)
+
+	if limit <= 0 {
+		limit = DefaultRelatedProductsLimit
+	}
+
+	err = c.initialize()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize client: %w", err)
 	}
-	statusCode, respBodyBytes, err := c.doRequest(productUrl, stockxHeader)
+
+	relatedUrl := c.endpoint(stockxRelatedProductsEndpointTemplate, productIdentifier, c.currency, c.locale, limit)
+	statusCode, respBodyBytes, err := c.doRequest("related products", relatedUrl)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
 	if statusCode != http.StatusOK {
-		return nil, fmt.Errorf("received wrong status code during product details request: %d", statusCode)
+		return nil, fmt.Errorf("received wrong status code during related products request: %d", statusCode)
 	}
 
-	response := ProductResponse{}
-	err = json.Unmarshal(respBodyBytes, &response)
+	response := RelatedProductsResponse{}
+	_, err = c.decodeResponse("related products", respBodyBytes, &response)
+
+	if err != nil {
+		return nil, err
+	}
+
+	relatedProducts := parseSearchResults(response.Products)
 
+	if len(relatedProducts) > limit {
+		relatedProducts = relatedProducts[:limit]
+	}
+
+	return relatedProducts, nil
+}
+
+func (c *client) GetSales(productIdentifier string, variantUUID string, page int) (sales []Sale, err error) {
+	defer c.observeOperation("GetSales", time.Now(), &err)
+
+	identifier := productIdentifier
+	if variantUUID != "" {
+		identifier = variantUUID
+	}
+
+	if page < 1 {
+		page = 1
+	}
+
+	response, err := c.getProductActivity(identifier, activityStateSales, stockxActivityPageSize, page)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseSales(response), nil
+}
+
+func (c *client) GetOrderBook(productIdentifier string, variantUUID string) (orderBook *OrderBook, err error) {
+	defer c.observeOperation("GetOrderBook", time.Now(), &err)
+
+	identifier := productIdentifier
+	if variantUUID != "" {
+		identifier = variantUUID
+	}
+
+	asks, err := c.getAllProductActivity(identifier, activityStateAsks)
 	if err != nil {
+		return nil, fmt.Errorf("failed to load asks: %w", err)
+	}
+
+	bids, err := c.getAllProductActivity(identifier, activityStateBids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bids: %w", err)
+	}
+
+	return parseOrderBook(asks, bids), nil
+}
+
+func (c *client) GetPriceHistory(productIdentifier string, numberOfPoints int, start time.Time, end time.Time, variantUUID string) (points []PricePoint, err error) {
+	defer c.observeOperation("GetPriceHistory", time.Now(), &err)
+
+	err = c.initialize()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize client: %w", err)
+	}
+
+	identifier := productIdentifier
+	if variantUUID != "" {
+		identifier = variantUUID
+	}
+
+	if numberOfPoints <= 0 {
+		numberOfPoints = stockxChartDefaultPoints
+	}
+
+	chartUrl := c.endpoint(stockxProductChartEndpointTemplate, identifier, start.Format(stockxChartDateLayout), end.Format(stockxChartDateLayout), numberOfPoints, c.currency, c.locale)
+	statusCode, respBodyBytes, err := c.doRequest("price history", chartUrl)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if statusCode != http.StatusOK {
+		return nil, fmt.Errorf("received wrong status code during price history request: %d", statusCode)
+	}
+
+	response := ProductChartResponse{}
+	_, err = c.decodeResponse("price history", respBodyBytes, &response)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return parsePriceHistory(response, c.currency), nil
+}
+
+func (c *client) getAllProductActivity(identifier string, state int) ([]ProductActivityItemResponse, error) {
+	var activities []ProductActivityItemResponse
+
+	for page := 1; page <= stockxOrderBookMaxPages; page++ {
+		response, err := c.getProductActivity(identifier, state, stockxOrderBookPageSize, page)
+		if err != nil {
+			return nil, err
+		}
+
+		activities = append(activities, response.ProductActivity...)
+
+		if len(response.ProductActivity) < stockxOrderBookPageSize {
+			break
+		}
+	}
+
+	return activities, nil
+}
+
+func (c *client) getProductActivity(identifier string, state int, limit int, page int) (*ProductActivityResponse, error) {
+	err := c.initialize()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize client: %w", err)
+	}
+
+	activityUrl := c.endpoint(stockxProductActivityEndpointTemplate, identifier, state, c.currency, c.locale, limit, page)
+	statusCode, respBodyBytes, err := c.doRequest("product activity", activityUrl)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if statusCode != http.StatusOK {
+		return nil, fmt.Errorf("received wrong status code during product activity request: %d", statusCode)
+	}
+
+	response := ProductActivityResponse{}
+	_, err = c.decodeResponse("product activity", respBodyBytes, &response)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// decodeResponse unmarshals the body into target and, depending on the schema mode, compares the body
+// with the target struct. The report is nil if schema checks are disabled.
+func (c *client) decodeResponse(endpoint string, body []byte, target interface{}) (*SchemaReport, error) {
+	err := json.Unmarshal(body, target)
+
+	if err != nil {
+		c.metrics.IncDecodeFailures(endpoint)
 		return nil, fmt.Errorf("failed to convert response json into response struct: %w", err)
 	}
 
-	product := parseProduct(response)
+	if c.schemaMode == SchemaModeOff {
+		return nil, nil
+	}
+
+	report, err := CheckSchema(body, target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check response schema: %w", err)
+	}
+
+	report.Endpoint = endpoint
+
+	for _, issue := range report.Issues {
+		c.logger.Warn("stockx api (%s) schema drift: %s", endpoint, issue.String())
+	}
+
+	if c.schemaMode == SchemaModeStrict && report.HasIssues() {
+		c.metrics.IncDecodeFailures(endpoint)
+		return report, &SchemaDriftError{Report: report}
+	}
+
+	return report, nil
+}
+
+func (c *client) endpoint(template string, args ...interface{}) string {
+	return c.baseUrl + fmt.Sprintf(template, args...)
+}
+
+func (c *client) doRequest(endpoint string, url string) (int, []byte, error) {
+	raw, err := c.doRawRequest(endpoint, url)
+	if raw == nil {
+		return 0, nil, err
+	}
+
+	return raw.statusCode, raw.body, err
+}
+
+// doRawRequest returns the response even if reading the body failed, it is only nil if no response was received.
+// Challenge responses are returned together with a *ChallengeError after the challenge handler gave up.
+// The headers are read per attempt and retries initialize the session again, so a challenge handler
+// calling RotateIdentity retries with the headers and cookies of the new identity.
+func (c *client) doRawRequest(endpoint string, url string) (*rawResponse, error) {
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && url != c.baseUrl {
+			err := c.initialize()
+			if err != nil {
+				return nil, fmt.Errorf("failed to initialize client for retry: %w", err)
+			}
+		}
+
+		raw, err := c.doSingleRequest(endpoint, url, c.requestHeader(), attempt)
+		if err != nil {
+			return raw, err
+		}
+
+		kind, isChallenge := DetectChallenge(raw.statusCode, raw.header, raw.body)
+		if !isChallenge {
+			return raw, nil
+		}
+
+		c.logger.Warn("stockx api (%s) served a %s challenge on attempt %d", url, kind, attempt)
+
+		challenge := &ChallengeError{
+			Kind:       kind,
+			StatusCode: raw.statusCode,
+			URL:        url,
+			Header:     raw.header,
+			Body:       raw.body,
+		}
+
+		if c.challengeHandler == nil || attempt > c.challengeRetries {
+			return raw, challenge
+		}
 
-	return product, nil
+		retry, err := c.challengeHandler.HandleChallenge(c, challenge, attempt)
+		if err != nil {
+			return raw, fmt.Errorf("failed to handle %s challenge: %w", kind, err)
+		}
+
+		if !retry {
+			return raw, challenge
+		}
+
+		c.metrics.IncRetries(endpoint)
+	}
 }
 
-func (c *client) doRequest(url string, header http.Header) (int, []byte, error) {
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+func (c *client) doSingleRequest(endpoint string, url string, header http.Header, attempt int) (raw *rawResponse, err error) {
+	c, span := c.startSpan("stockx.request", trace.SpanKindClient,
+		attribute.String("stockx.endpoint", endpoint),
+		attribute.String("http.method", http.MethodGet),
+		attribute.String("http.url", url),
+		attribute.Int("stockx.attempt", attempt),
+		attribute.String("stockx.proxy", redactedProxy(c.transport.GetProxy())),
+	)
+	defer func() {
+		if raw != nil {
+			span.SetAttributes(attribute.Int("http.status_code", raw.statusCode))
+			if err == nil && raw.statusCode >= http.StatusBadRequest {
+				span.SetStatus(codes.Error, fmt.Sprintf("received status code %d", raw.statusCode))
+			}
+		}
+
+		endSpan(span, err)
+	}()
+
+	req, err := http.NewRequestWithContext(c.ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return 0, nil, fmt.Errorf("failed to create stockx search request: %w", err)
+		return nil, fmt.Errorf("failed to create stockx search request: %w", err)
 	}
 
-	req.Header = header
+	req.Header = c.buildRequestHeader(url, header)
 
-	resp, err := c.httpClient.Do(req)
+	start := time.Now()
+	resp, err := c.transport.Do(req)
 
 	if err != nil {
-		return 0, nil, fmt.Errorf("failed to search for stockx products: %w", err)
+		c.metrics.ObserveRequest(endpoint, 0, time.Since(start))
+		if c.transport.GetProxy() != "" {
+			c.metrics.IncProxyFailures(endpoint)
+		}
+
+		return nil, fmt.Errorf("failed to search for stockx products: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusProxyAuthRequired {
+		c.metrics.IncProxyFailures(endpoint)
 	}
 
 	c.logger.Info("stockx api (%s) response status code: %d", url, resp.StatusCode)
@@ -230,52 +832,251 @@ func (c *client) doRequest(url string, header http.Header) (int, []byte, error)
 
 	respBodyBytes, err := ioutil.ReadAll(resp.Body)
 
+	c.metrics.ObserveRequest(endpoint, resp.StatusCode, time.Since(start))
+	if _, replayed := c.transport.(*replayTransport); replayed {
+		c.metrics.IncCacheHits(endpoint)
+	}
+
 	c.logger.Debug("stockx api (%s) response body: %s", url, string(respBodyBytes))
 
-	return resp.StatusCode, respBodyBytes, err
+	return &rawResponse{
+		statusCode: resp.StatusCode,
+		body:       respBodyBytes,
+		header:     resp.Header,
+		fetchedAt:  time.Now(),
+	}, err
+}
+
+// MatchesStyleID compares style ids ignoring case and the space / dash separator. Stockx sometimes
+// lists several style ids for one product separated by a slash, any of them matches.
+func MatchesStyleID(productStyleID string, styleID string) bool {
+	wanted := NormalizeStyleID(styleID)
+	if wanted == "" {
+		return false
+	}
+
+	for _, candidate := range strings.Split(productStyleID, "/") {
+		if NormalizeStyleID(candidate) == wanted {
+			return true
+		}
+	}
+
+	return false
 }
 
-func parseSearchResults(response ProductSearchResultResponse) []SearchResultProduct {
+// NormalizeStyleID upper cases a style id and separates its parts with a single dash.
+func NormalizeStyleID(styleID string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(strings.ReplaceAll(strings.TrimSpace(styleID), "-", " ")), "-"))
+}
+
+func parseSearchResults(products []SearchResultProductResponse) []SearchResultProduct {
 	var searchResultProducts []SearchResultProduct
 
-	for _, responseProduct := range response.Products {
+	for _, responseProduct := range products {
 		searchResultProducts = append(searchResultProducts, SearchResultProduct{
-			Brand:             responseProduct.Brand,
-			Colorway:          responseProduct.Colorway,
-			ImageUrl:          responseProduct.Media.Thumburl,
-			Category:          responseProduct.Productcategory,
-			Description:       responseProduct.Shortdescription,
-			Title:             responseProduct.Title,
-			ProductIdentifier: responseProduct.Urlkey,
+			Brand:             string(responseProduct.Brand),
+			Colorway:          string(responseProduct.Colorway),
+			ImageUrl:          string(responseProduct.Media.Thumburl),
+			Category:          string(responseProduct.Productcategory),
+			Description:       string(responseProduct.Shortdescription),
+			Title:             string(responseProduct.Title),
+			ProductIdentifier: string(responseProduct.Urlkey),
 		})
 	}
 
 	return searchResultProducts
 }
 
+func parseSales(response *ProductActivityResponse) []Sale {
+	var sales []Sale
+
+	for _, activity := range response.ProductActivity {
+		amount := float64(activity.Localamount)
+		if amount == 0 {
+			amount = float64(activity.Amount)
+		}
+
+		sales = append(sales, Sale{
+			Amount:      amount,
+			Size:        string(activity.Shoesize),
+			Time:        activity.Createdat.Time,
+			VariantUUID: string(activity.Skuuuid),
+		})
+	}
+
+	return sales
+}
+
+func parsePriceHistory(response ProductChartResponse, currency string) []PricePoint {
+	var points []PricePoint
+
+	for _, series := range response.Series {
+		for _, data := range series.Data {
+			if len(data) < 2 {
+				continue
+			}
+
+			points = append(points, PricePoint{
+				Time:     time.Unix(0, int64(data[0])*int64(time.Millisecond)).UTC(),
+				Price:    float64(data[1]),
+				Currency: currency,
+			})
+		}
+	}
+
+	sort.SliceStable(points, func(i, j int) bool {
+		return points[i].Time.Before(points[j].Time)
+	})
+
+	return points
+}
+
+func parseOrderBook(asks []ProductActivityItemResponse, bids []ProductActivityItemResponse) *OrderBook {
+	orderBook := &OrderBook{
+		Asks: parsePriceLevels(asks),
+		Bids: parsePriceLevels(bids),
+	}
+
+	sort.SliceStable(orderBook.Asks, func(i, j int) bool {
+		return orderBook.Asks[i].Price < orderBook.Asks[j].Price
+	})
+
+	sort.SliceStable(orderBook.Bids, func(i, j int) bool {
+		return orderBook.Bids[i].Price > orderBook.Bids[j].Price
+	})
+
+	return orderBook
+}
+
+func parsePriceLevels(activities []ProductActivityItemResponse) []PriceLevel {
+	var levels []PriceLevel
+	index := map[string]int{}
+
+	for _, activity := range activities {
+		price := float64(activity.Localamount)
+		if price == 0 {
+			price = float64(activity.Amount)
+		}
+
+		quantity := int(activity.Frequency)
+		if quantity == 0 {
+			quantity = 1
+		}
+
+		key := fmt.Sprintf("%s|%f", activity.Shoesize, price)
+		if i, ok := index[key]; ok {
+			levels[i].Quantity += quantity
+			continue
+		}
+
+		index[key] = len(levels)
+		levels = append(levels, PriceLevel{
+			Price:       price,
+			Quantity:    quantity,
+			Size:        string(activity.Shoesize),
+			VariantUUID: string(activity.Skuuuid),
+		})
+	}
+
+	return levels
+}
+
+// parseVariant converts a child of a product into a variant. Children without any size are no variants,
+// parseVariant returns false for them.
+func parseVariant(key string, responseVariant ProductWithoutChildren) (ProductDetailsVariant, bool) {
+	market := responseVariant.Market
+
+	size := string(market.Lastsalesize)
+	if size == "" {
+		size = string(responseVariant.Shoesize)
+	}
+
+	if size == "" {
+		return ProductDetailsVariant{}, false
+	}
+
+	return ProductDetailsVariant{
+		UUID:             key,
+		Size:             size,
+		GTINs:            parseGtins(responseVariant.Gtins),
+		Lowestask:        int(market.Lowestask),
+		Highestbid:       int(market.Highestbid),
+		Annualhigh:       int(market.Annualhigh),
+		Annuallow:        int(market.Annuallow),
+		Lastsale:         int(market.Lastsale),
+		Saleslast72Hours: int(market.Saleslast72Hours),
+		Lastsaledate:     market.Lastsaledate.Time,
+		Lowestaskfloat:   float64(market.Lowestaskfloat),
+		Highestbidfloat:  float64(market.Highestbidfloat),
+		Market:           market,
+	}, true
+}
+
+func parseGtins(responseGtins []Gtin) []string {
+	var gtins []string
+	for _, gtin := range responseGtins {
+		gtins = append(gtins, string(gtin.Identifier))
+	}
+
+	return gtins
+}
+
+func parseBreadcrumbs(responseBreadcrumbs []Breadcrumb) []ProductDetailsBreadcrumb {
+	var breadcrumbs []ProductDetailsBreadcrumb
+	for _, breadcrumb := range responseBreadcrumbs {
+		breadcrumbs = append(breadcrumbs, ProductDetailsBreadcrumb{
+			Level: int(breadcrumb.Level),
+			Name:  string(breadcrumb.Name),
+			URL:   string(breadcrumb.URL),
+		})
+	}
+
+	return breadcrumbs
+}
+
+func parseShipping(shipping Shipping) ProductDetailsShipping {
+	return ProductDetailsShipping{
+		Totaldaystoship:         int(shipping.Totaldaystoship),
+		Hasadditionaldaystoship: bool(shipping.Hasadditionaldaystoship),
+		Deliverydayslowerbound:  int(shipping.Deliverydayslowerbound),
+		Deliverydaysupperbound:  int(shipping.Deliverydaysupperbound),
+	}
+}
+
+func parseEnhancedImage(enhancedImage EnhancedImage) ProductDetailsEnhancedImage {
+	return ProductDetailsEnhancedImage{
+		Productuuid: string(enhancedImage.Productuuid),
+		Imagekey:    string(enhancedImage.Imagekey),
+		Imagecount:  int(enhancedImage.Imagecount),
+	}
+}
+
+// NormalizeGTIN strips everything but digits and leading zeros so that UPC-A (12 digits),
+// EAN-13 and GTIN-14 representations of the same code compare equal.
+func NormalizeGTIN(gtin string) string {
+	var digits strings.Builder
+
+	for _, char := range gtin {
+		if char >= '0' && char <= '9' {
+			digits.WriteRune(char)
+		}
+	}
+
+	return strings.TrimLeft(digits.String(), "0")
+}
+
 func parseProduct(response ProductResponse) *ProductDetails {
 	var variants []ProductDetailsVariant
 
 	product := response.Product
 
 	for key, responseVariant := range product.Children {
-		if responseVariant.Market.Lastsalesize == "" {
+		variant, ok := parseVariant(key, responseVariant)
+		if !ok {
 			continue
 		}
 
-		variants = append(variants, ProductDetailsVariant{
-			UUID:             key,
-			Size:             responseVariant.Market.Lastsalesize,
-			Lowestask:        responseVariant.Market.Lowestask,
-			Highestbid:       responseVariant.Market.Highestbid,
-			Annualhigh:       responseVariant.Market.Annualhigh,
-			Annuallow:        responseVariant.Market.Annuallow,
-			Lastsale:         responseVariant.Market.Lastsale,
-			Saleslast72Hours: responseVariant.Market.Saleslast72Hours,
-			Lastsaledate:     responseVariant.Market.Lastsaledate,
-			Lowestaskfloat:   responseVariant.Market.Lowestaskfloat,
-			Highestbidfloat:  responseVariant.Market.Highestbidfloat,
-		})
+		variants = append(variants, variant)
 	}
 
 	sort.Slice(variants, func(i, j int) bool {
@@ -290,29 +1091,93 @@ func parseProduct(response ProductResponse) *ProductDetails {
 	})
 
 	return &ProductDetails{
-		ID:                product.ID,
-		UUID:              product.UUID,
-		Brand:             product.Brand,
-		Colorway:          product.Colorway,
-		Minimumbid:        product.Minimumbid,
-		Name:              product.Name,
-		Releasedate:       product.Releasedate,
-		Retailprice:       product.Retailprice,
-		Shoe:              product.Shoe,
-		Shortdescription:  product.Shortdescription,
-		Styleid:           product.Styleid,
-		Title:             product.Title,
-		SizeLocale:        product.Sizelocale,
-		SizeTitle:         product.Sizetitle,
-		ProductIdentifier: product.Urlkey,
-		Description:       product.Description,
-		Imageurl:          product.Media.Imageurl,
-		Smallimageurl:     product.Media.Smallimageurl,
-		Thumburl:          product.Media.Thumburl,
-		Lowestaskfloat:    product.Market.Lowestaskfloat,
-		Lowestask:         product.Market.Lowestask,
-		Highestbid:        product.Market.Highestbid,
-		Highestbidfloat:   product.Market.Highestbidfloat,
+		ID:                string(product.ID),
+		UUID:              string(product.UUID),
+		Brand:             string(product.Brand),
+		Colorway:          string(product.Colorway),
+		Minimumbid:        int(product.Minimumbid),
+		Name:              string(product.Name),
+		Releasedate:       string(product.Releasedate),
+		Retailprice:       int(product.Retailprice),
+		Shoe:              string(product.Shoe),
+		Shortdescription:  string(product.Shortdescription),
+		Styleid:           string(product.Styleid),
+		Title:             string(product.Title),
+		SizeLocale:        string(product.Sizelocale),
+		SizeTitle:         string(product.Sizetitle),
+		ProductIdentifier: string(product.Urlkey),
+		Description:       string(product.Description),
+		Imageurl:          string(product.Media.Imageurl),
+		Smallimageurl:     string(product.Media.Smallimageurl),
+		Thumburl:          string(product.Media.Thumburl),
+		Lowestaskfloat:    float64(product.Market.Lowestaskfloat),
+		Lowestask:         int(product.Market.Lowestask),
+		Highestbid:        int(product.Market.Highestbid),
+		Highestbidfloat:   float64(product.Market.Highestbidfloat),
 		Variants:          variants,
+
+		ReleaseTime:          parseReleaseDate(string(product.Releasedate)),
+		Gender:               string(product.Gender),
+		Condition:            string(product.Condition),
+		Countryofmanufacture: string(product.Countryofmanufacture),
+		Primarycategory:      string(product.Primarycategory),
+		Secondarycategory:    string(product.Secondarycategory),
+		Productcategory:      string(product.Productcategory),
+		Tickersymbol:         string(product.Tickersymbol),
+		Year:                 int(product.Year),
+		Breadcrumbs:          parseBreadcrumbs(product.Breadcrumbs),
+		Shipping:             parseShipping(product.Shipping),
+		Images360:            parseFlexStrings(product.Media.Num360),
+		Gallery:              parseGallery(product.Media.Gallery),
+		Enhancedimage:        parseEnhancedImage(product.Enhancedimage),
+		Market:               product.Market,
+	}
+}
+
+var releaseDateLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	time.RFC3339,
+}
+
+// parseReleaseDate returns the zero time for unknown or empty release dates.
+func parseReleaseDate(releaseDate string) time.Time {
+	for _, layout := range releaseDateLayouts {
+		parsed, err := time.ParseInLocation(layout, strings.TrimSpace(releaseDate), time.UTC)
+		if err == nil {
+			return parsed
+		}
+	}
+
+	return time.Time{}
+}
+
+func parseFlexStrings(values []FlexString) []string {
+	var strs []string
+	for _, value := range values {
+		strs = append(strs, string(value))
+	}
+
+	return strs
+}
+
+// parseGallery keeps the image urls of the gallery, entries can either be plain urls or objects with an url field.
+func parseGallery(gallery []interface{}) []string {
+	var images []string
+
+	for _, entry := range gallery {
+		switch value := entry.(type) {
+		case string:
+			images = append(images, value)
+		case map[string]interface{}:
+			for _, key := range []string{"imageUrl", "url"} {
+				if imageUrl, ok := value[key].(string); ok && imageUrl != "" {
+					images = append(images, imageUrl)
+					break
+				}
+			}
+		}
 	}
+
+	return images
 }
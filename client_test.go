@@ -0,0 +1,203 @@
+package go_stockx_client_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	http "github.com/bogdanfinn/fhttp"
+	go_stockx_client "github.com/bogdanfinn/go-stockx-client"
+	"github.com/bogdanfinn/go-stockx-client/stockxtest"
+)
+
+const orderBookActivityFixture = `{
+	"Pagination": {"limit": 100, "page": 1, "total": 3},
+	"ProductActivity": [
+		{"amount": 125, "localAmount": 120, "shoeSize": "10", "skuUuid": "2b1fb7d8-6b9c-4b4d-8d9f-6c8b2c1e7a02", "frequency": 2},
+		{"amount": "118", "localAmount": "110", "shoeSize": "10", "skuUuid": "2b1fb7d8-6b9c-4b4d-8d9f-6c8b2c1e7a02"},
+		{"amount": 125, "localAmount": 120, "shoeSize": "10", "skuUuid": "2b1fb7d8-6b9c-4b4d-8d9f-6c8b2c1e7a02"}
+	]
+}`
+
+func TestGetOrderBook(t *testing.T) {
+	server := stockxtest.NewServer()
+	defer server.Close()
+
+	// asks and bids are requested from the same path with a different state, both are served the fixture
+	server.SetResponse("/api/products/"+stockxtest.DefaultProductURLKey+"/activity", http.StatusOK, []byte(orderBookActivityFixture))
+
+	orderBook, err := newTestClient(t, server).GetOrderBook(stockxtest.DefaultProductURLKey, "")
+	if err != nil {
+		t.Fatalf("failed to load order book: %v", err)
+	}
+
+	if len(orderBook.Asks) != 2 || orderBook.Asks[0].Price != 110 || orderBook.Asks[0].Quantity != 1 || orderBook.Asks[1].Price != 120 || orderBook.Asks[1].Quantity != 3 {
+		t.Fatalf("unexpected asks: %+v", orderBook.Asks)
+	}
+
+	if len(orderBook.Bids) != 2 || orderBook.Bids[0].Price != 120 || orderBook.Bids[1].Price != 110 {
+		t.Fatalf("unexpected bids: %+v", orderBook.Bids)
+	}
+
+	cost, err := orderBook.CostToBuy(2)
+	if err != nil || cost != 230 {
+		t.Fatalf("expected a cost of 230, got %.0f, %v", cost, err)
+	}
+
+	requests := server.RequestsTo("/api/products/" + stockxtest.DefaultProductURLKey + "/activity")
+	if len(requests) != 2 || requests[0].Query.Get("state") != "400" || requests[1].Query.Get("state") != "300" {
+		t.Fatalf("expected one ask and one bid request, got %+v", requests)
+	}
+}
+
+func TestGetOrderBookStatusError(t *testing.T) {
+	server := stockxtest.NewServer()
+	defer server.Close()
+
+	if _, err := newTestClient(t, server).GetOrderBook(stockxtest.DefaultProductURLKey, ""); err == nil {
+		t.Fatalf("expected an error for the missing activity endpoint")
+	}
+}
+
+func relatedProductsFixture(t *testing.T, count int) []byte {
+	t.Helper()
+
+	var products []map[string]string
+	for i := 0; i < count; i++ {
+		products = append(products, map[string]string{
+			"brand":  "Nike",
+			"title":  fmt.Sprintf("Nike Dunk Low %d", i),
+			"urlKey": fmt.Sprintf("nike-dunk-low-%d", i),
+		})
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"Products": products})
+	if err != nil {
+		t.Fatalf("failed to encode related products: %v", err)
+	}
+
+	return body
+}
+
+func TestGetRelatedProducts(t *testing.T) {
+	server := stockxtest.NewServer()
+	defer server.Close()
+
+	relatedPath := "/api/products/" + stockxtest.DefaultProductURLKey + "/related"
+	server.SetResponse(relatedPath, http.StatusOK, relatedProductsFixture(t, 12))
+
+	client := newTestClient(t, server)
+
+	tests := []struct {
+		limit         int
+		expectedLimit int
+	}{
+		{limit: 3, expectedLimit: 3},
+		{limit: 0, expectedLimit: go_stockx_client.DefaultRelatedProductsLimit},
+		{limit: -1, expectedLimit: go_stockx_client.DefaultRelatedProductsLimit},
+	}
+
+	for _, test := range tests {
+		server.Reset()
+
+		products, err := client.GetRelatedProducts(stockxtest.DefaultProductURLKey, test.limit)
+		if err != nil {
+			t.Fatalf("failed to load related products with limit %d: %v", test.limit, err)
+		}
+
+		if len(products) != test.expectedLimit || products[0].ProductIdentifier != "nike-dunk-low-0" {
+			t.Fatalf("expected %d related products for limit %d, got %+v", test.expectedLimit, test.limit, products)
+		}
+
+		requests := server.RequestsTo(relatedPath)
+		if len(requests) != 1 || requests[0].Query.Get("limit") != fmt.Sprint(test.expectedLimit) {
+			t.Fatalf("expected limit %d to be requested for limit %d, got %+v", test.expectedLimit, test.limit, requests)
+		}
+	}
+
+	server.SetResponse(relatedPath, http.StatusNotFound, []byte(`{"error": "Not Found"}`))
+
+	if _, err := client.GetRelatedProducts(stockxtest.DefaultProductURLKey, 3); err == nil {
+		t.Fatalf("expected an error for a non-200 response")
+	}
+}
+
+func TestProductResult(t *testing.T) {
+	server := stockxtest.NewServer()
+	defer server.Close()
+
+	client := newTestClient(t, server)
+
+	result, err := client.GetProductResult(stockxtest.DefaultProductURLKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Value == nil || result.Value.UUID != stockxtest.DefaultProductUUID {
+		t.Fatalf("unexpected product: %+v", result.Value)
+	}
+
+	if result.Status != http.StatusOK || !bytes.Equal(result.Raw, stockxtest.DefaultProductFixture()) || result.Header.Get("Content-Type") != "application/json" || result.FetchedAt.IsZero() {
+		t.Fatalf("unexpected response data: %d %v %s", result.Status, result.Header, result.Raw)
+	}
+
+	result, err = client.GetProductResult("unknown-product")
+	if !errors.Is(err, go_stockx_client.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	if result == nil || result.Value != nil || result.Status != http.StatusNotFound || !bytes.Contains(result.Raw, []byte("Not Found")) || result.Header.Get("Content-Type") != "application/json" {
+		t.Fatalf("expected the not found response in the result, got %+v", result)
+	}
+
+	server.Fail(stockxtest.MalformedJSON().On("/api/products/"))
+
+	result, err = client.GetProductResult(stockxtest.DefaultProductURLKey)
+	if err == nil {
+		t.Fatalf("expected a decode error")
+	}
+
+	if result == nil || result.Value != nil || result.Status != http.StatusOK || len(result.Raw) == 0 || result.Header.Get("Content-Type") != "application/json" {
+		t.Fatalf("expected the malformed response in the result, got %+v", result)
+	}
+}
+
+func TestSearchProductsResult(t *testing.T) {
+	server := stockxtest.NewServer()
+	defer server.Close()
+
+	client := newTestClient(t, server)
+
+	result, err := client.SearchProductsResult("dunk low", 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Value) != 1 || result.Status != http.StatusOK || !bytes.Equal(result.Raw, stockxtest.DefaultSearchFixture()) || result.Header.Get("Content-Type") != "application/json" {
+		t.Fatalf("unexpected search result: %+v", result)
+	}
+
+	server.Fail(stockxtest.RateLimited().On("/api/browse"))
+
+	result, err = client.SearchProductsResult("dunk low", 10)
+	if err == nil {
+		t.Fatalf("expected a status error")
+	}
+
+	if result == nil || result.Value != nil || result.Status != http.StatusTooManyRequests || result.Header.Get("Retry-After") != "1" || !bytes.Contains(result.Raw, []byte("Too Many Requests")) {
+		t.Fatalf("expected the rate limited response in the result, got %+v", result)
+	}
+
+	server.Fail(stockxtest.MalformedJSON().On("/api/browse"))
+
+	result, err = client.SearchProductsResult("dunk low", 10)
+	if err == nil {
+		t.Fatalf("expected a decode error")
+	}
+
+	if result == nil || result.Value != nil || result.Status != http.StatusOK || len(result.Raw) == 0 {
+		t.Fatalf("expected the malformed response in the result, got %+v", result)
+	}
+}
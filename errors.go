@@ -0,0 +1,11 @@
+package go_stockx_client
+
+import "errors"
+
+var (
+	ErrNotFound              = errors.New("product not found")
+	ErrAmbiguous             = errors.New("multiple products match")
+	ErrInvalidProductRef     = errors.New("invalid product reference")
+	ErrInsufficientLiquidity = errors.New("not enough orders in the order book")
+	ErrChallenge             = errors.New("bot protection challenge")
+)
@@ -0,0 +1,249 @@
+package go_stockx_client
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The flexible types below are used in the response structs for fields stockx does not send consistently.
+// They accept every json scalar (strings, numbers, booleans and null) and never fail the decoding of the
+// whole response: values which can not be converted are left at the zero value. Objects and arrays are
+// ignored as well, schema drift detection reports them as type mismatches.
+
+// FlexString accepts strings, numbers and booleans.
+type FlexString string
+
+// FlexInt accepts integers, floats (rounded), numeric strings and booleans.
+type FlexInt int
+
+// FlexFloat accepts numbers, numeric strings and booleans (true is 1).
+type FlexFloat float64
+
+// FlexBool accepts booleans, numbers (0 is false) and the strings "true", "false", "1" and "0".
+type FlexBool bool
+
+// NullableTime accepts RFC 3339 and "2006-01-02 15:04:05" strings as well as unix timestamps in
+// seconds or milliseconds. Valid is false for null, empty and unparsable values.
+type NullableTime struct {
+	Time  time.Time
+	Valid bool
+}
+
+// flexible is implemented by the flexible types so that CheckSchema can tell which json values they accept.
+type flexible interface {
+	acceptsJsonKind(kind string) bool
+}
+
+func acceptsScalar(kind string) bool {
+	return kind != "object" && kind != "array"
+}
+
+func (f *FlexString) UnmarshalJSON(data []byte) error {
+	value, ok := decodeScalar(data)
+	if !ok {
+		*f = ""
+		return nil
+	}
+
+	switch typed := value.(type) {
+	case string:
+		*f = FlexString(typed)
+	case json.Number:
+		*f = FlexString(typed.String())
+	case bool:
+		*f = FlexString(strconv.FormatBool(typed))
+	default:
+		*f = ""
+	}
+
+	return nil
+}
+
+func (f FlexString) String() string {
+	return string(f)
+}
+
+func (f FlexString) acceptsJsonKind(kind string) bool {
+	return acceptsScalar(kind)
+}
+
+func (f *FlexInt) UnmarshalJSON(data []byte) error {
+	number, ok := decodeNumber(data)
+	if !ok {
+		*f = 0
+		return nil
+	}
+
+	*f = FlexInt(math.Round(number))
+
+	return nil
+}
+
+func (f FlexInt) Int() int {
+	return int(f)
+}
+
+func (f FlexInt) acceptsJsonKind(kind string) bool {
+	return acceptsScalar(kind)
+}
+
+func (f *FlexFloat) UnmarshalJSON(data []byte) error {
+	number, ok := decodeNumber(data)
+	if !ok {
+		*f = 0
+		return nil
+	}
+
+	*f = FlexFloat(number)
+
+	return nil
+}
+
+func (f FlexFloat) Float64() float64 {
+	return float64(f)
+}
+
+func (f FlexFloat) acceptsJsonKind(kind string) bool {
+	return acceptsScalar(kind)
+}
+
+func (f *FlexBool) UnmarshalJSON(data []byte) error {
+	value, ok := decodeScalar(data)
+	if !ok {
+		*f = false
+		return nil
+	}
+
+	switch typed := value.(type) {
+	case bool:
+		*f = FlexBool(typed)
+	case json.Number:
+		number, err := typed.Float64()
+		*f = FlexBool(err == nil && number != 0)
+	case string:
+		parsed, err := strconv.ParseBool(strings.TrimSpace(typed))
+		*f = FlexBool(err == nil && parsed)
+	default:
+		*f = false
+	}
+
+	return nil
+}
+
+func (f FlexBool) Bool() bool {
+	return bool(f)
+}
+
+func (f FlexBool) acceptsJsonKind(kind string) bool {
+	return acceptsScalar(kind)
+}
+
+var nullableTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+func (t *NullableTime) UnmarshalJSON(data []byte) error {
+	*t = NullableTime{}
+
+	value, ok := decodeScalar(data)
+	if !ok {
+		return nil
+	}
+
+	switch typed := value.(type) {
+	case string:
+		typed = strings.TrimSpace(typed)
+
+		for _, layout := range nullableTimeLayouts {
+			parsed, err := time.Parse(layout, typed)
+			if err == nil {
+				*t = NullableTime{Time: parsed, Valid: true}
+				return nil
+			}
+		}
+
+		if number, err := strconv.ParseFloat(typed, 64); err == nil {
+			*t = unixToNullableTime(number)
+		}
+	case json.Number:
+		number, err := typed.Float64()
+		if err == nil {
+			*t = unixToNullableTime(number)
+		}
+	}
+
+	return nil
+}
+
+func (t NullableTime) MarshalJSON() ([]byte, error) {
+	if !t.Valid {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(t.Time)
+}
+
+func (t NullableTime) acceptsJsonKind(kind string) bool {
+	return kind == "string" || kind == "integer" || kind == "float" || kind == "null"
+}
+
+// unixToNullableTime treats values above 1e11 as milliseconds, everything below would be before 1973 in milliseconds.
+func unixToNullableTime(number float64) NullableTime {
+	if number <= 0 {
+		return NullableTime{}
+	}
+
+	if number > 1e11 {
+		return NullableTime{Time: time.Unix(0, int64(number)*int64(time.Millisecond)).UTC(), Valid: true}
+	}
+
+	return NullableTime{Time: time.Unix(int64(number), 0).UTC(), Valid: true}
+}
+
+func decodeScalar(data []byte) (interface{}, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil || value == nil {
+		return nil, false
+	}
+
+	switch value.(type) {
+	case string, json.Number, bool:
+		return value, true
+	default:
+		return nil, false
+	}
+}
+
+func decodeNumber(data []byte) (float64, bool) {
+	value, ok := decodeScalar(data)
+	if !ok {
+		return 0, false
+	}
+
+	switch typed := value.(type) {
+	case json.Number:
+		number, err := typed.Float64()
+		return number, err == nil
+	case string:
+		number, err := strconv.ParseFloat(strings.TrimSpace(typed), 64)
+		return number, err == nil
+	case bool:
+		if typed {
+			return 1, true
+		}
+
+		return 0, true
+	default:
+		return 0, false
+	}
+}
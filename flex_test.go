@@ -0,0 +1,123 @@
+package go_stockx_client
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata/golden")
+
+// The responses in testdata/responses are captured from stockx and edited to contain the odd shapes seen in the
+// wild: numbers as strings, integers as floats, null and millisecond timestamps. The parsed results are compared
+// with testdata/golden, run "go test -run TestGolden -update" after intended changes.
+func TestGoldenResponses(t *testing.T) {
+	tests := []struct {
+		name  string
+		parse func(t *testing.T, body []byte) interface{}
+	}{
+		{
+			name: "product",
+			parse: func(t *testing.T, body []byte) interface{} {
+				response := ProductResponse{}
+				decodeGoldenResponse(t, body, &response)
+
+				return parseProduct(response)
+			},
+		},
+		{
+			name: "search",
+			parse: func(t *testing.T, body []byte) interface{} {
+				response := ProductSearchResultResponse{}
+				decodeGoldenResponse(t, body, &response)
+
+				return parseSearchResults(response.Products)
+			},
+		},
+		{
+			name: "activity",
+			parse: func(t *testing.T, body []byte) interface{} {
+				response := ProductActivityResponse{}
+				decodeGoldenResponse(t, body, &response)
+
+				return parseSales(&response)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			body, err := ioutil.ReadFile(filepath.Join("testdata", "responses", test.name+".json"))
+			if err != nil {
+				t.Fatalf("failed to read response: %v", err)
+			}
+
+			actual, err := json.MarshalIndent(test.parse(t, body), "", "  ")
+			if err != nil {
+				t.Fatalf("failed to encode parsed response: %v", err)
+			}
+
+			actual = append(actual, '\n')
+			goldenPath := filepath.Join("testdata", "golden", test.name+".json")
+
+			if *updateGolden {
+				if err = ioutil.WriteFile(goldenPath, actual, 0o644); err != nil {
+					t.Fatalf("failed to update golden file: %v", err)
+				}
+			}
+
+			expected, err := ioutil.ReadFile(goldenPath)
+			if err != nil {
+				t.Fatalf("failed to read golden file: %v", err)
+			}
+
+			if !bytes.Equal(expected, actual) {
+				t.Errorf("parsed %s response differs from %s:\n%s", test.name, goldenPath, actual)
+			}
+		})
+	}
+}
+
+func decodeGoldenResponse(t *testing.T, body []byte, target interface{}) {
+	t.Helper()
+
+	if err := json.Unmarshal(body, target); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+}
+
+func TestFlexTypes(t *testing.T) {
+	target := struct {
+		String FlexString   `json:"string"`
+		Int    FlexInt      `json:"int"`
+		Float  FlexFloat    `json:"float"`
+		Bool   FlexBool     `json:"bool"`
+		Time   NullableTime `json:"time"`
+	}{}
+
+	err := json.Unmarshal([]byte(`{"string": 42, "int": "41.6", "float": true, "bool": "1", "time": "1668864000000"}`), &target)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if target.String != "42" || target.Int != 42 || target.Float != 1 || !target.Bool.Bool() {
+		t.Fatalf("unexpected values: %+v", target)
+	}
+
+	if !target.Time.Valid || !target.Time.Time.Equal(time.Date(2022, 11, 19, 13, 20, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected time: %+v", target.Time)
+	}
+
+	err = json.Unmarshal([]byte(`{"string": {}, "int": [1], "float": "n/a", "bool": null, "time": null}`), &target)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if target.String != "" || target.Int != 0 || target.Float != 0 || target.Bool.Bool() || target.Time.Valid {
+		t.Fatalf("expected zero values, got %+v", target)
+	}
+}
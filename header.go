@@ -0,0 +1,100 @@
+package go_stockx_client
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	http "github.com/bogdanfinn/fhttp"
+)
+
+// HeaderBuilder adjusts the headers of a single request. It receives a copy of the headers, changes never
+// leak into other requests. Header names are case insensitive, a header set by a builder replaces the
+// header of the browser identity. ctx is the context of the request, see Client.WithContext.
+// Builders are called concurrently and must be safe for concurrent use.
+type HeaderBuilder interface {
+	BuildHeader(ctx context.Context, url string, header http.Header)
+}
+
+type HeaderBuilderFunc func(ctx context.Context, url string, header http.Header)
+
+func (f HeaderBuilderFunc) BuildHeader(ctx context.Context, url string, header http.Header) {
+	f(ctx, url, header)
+}
+
+type contextHeaderKey struct{}
+
+// ContextWithHeader returns a copy of ctx carrying a header for the requests of a client bound to it with
+// WithContext, for example to send another accept-language for a single call:
+// client.WithContext(ContextWithHeader(ctx, "accept-language", "fr-FR")).GetProduct(urlKey)
+// Context headers replace the headers of the browser identity and headers set by header builders.
+func ContextWithHeader(ctx context.Context, key string, value string) context.Context {
+	header := map[string]string{}
+	if parent, ok := ctx.Value(contextHeaderKey{}).(map[string]string); ok {
+		for parentKey, parentValue := range parent {
+			header[parentKey] = parentValue
+		}
+	}
+
+	header[strings.ToLower(key)] = value
+
+	return context.WithValue(ctx, contextHeaderKey{}, header)
+}
+
+// buildRequestHeader returns a copy of header with all header builders and the headers of the client context applied.
+func (c *client) buildRequestHeader(url string, header http.Header) http.Header {
+	requestHeader := header.Clone()
+	contextHeader, _ := c.ctx.Value(contextHeaderKey{}).(map[string]string)
+
+	if len(c.headerBuilders) == 0 && len(contextHeader) == 0 {
+		return requestHeader
+	}
+
+	for _, builder := range c.headerBuilders {
+		builder.BuildHeader(c.ctx, url, requestHeader)
+	}
+
+	for key, value := range contextHeader {
+		requestHeader.Del(key)
+		requestHeader[key] = []string{value}
+	}
+
+	return normalizeHeader(requestHeader)
+}
+
+// normalizeHeader lower cases all header names like browsers send them over http2, canonical names set by
+// header builders win over the lower case defaults. Headers missing in the header order are appended to it.
+func normalizeHeader(header http.Header) http.Header {
+	normalized := http.Header{}
+
+	for key, values := range header {
+		if key == http.HeaderOrderKey || key == http.PHeaderOrderKey {
+			normalized[key] = values
+			continue
+		}
+
+		lowerKey := strings.ToLower(key)
+		if _, exists := normalized[lowerKey]; exists && lowerKey == key {
+			continue
+		}
+
+		normalized[lowerKey] = values
+	}
+
+	ordered := map[string]bool{}
+	for _, key := range normalized[http.HeaderOrderKey] {
+		ordered[key] = true
+	}
+
+	var missing []string
+	for key := range normalized {
+		if key != http.HeaderOrderKey && key != http.PHeaderOrderKey && !ordered[key] {
+			missing = append(missing, key)
+		}
+	}
+
+	sort.Strings(missing)
+	normalized[http.HeaderOrderKey] = append(append([]string(nil), normalized[http.HeaderOrderKey]...), missing...)
+
+	return normalized
+}
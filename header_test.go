@@ -0,0 +1,168 @@
+package go_stockx_client_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	http "github.com/bogdanfinn/fhttp"
+	go_stockx_client "github.com/bogdanfinn/go-stockx-client"
+	"github.com/bogdanfinn/go-stockx-client/stockxtest"
+)
+
+func TestContextHeaderOverridesSingleCall(t *testing.T) {
+	server := stockxtest.NewServer()
+	defer server.Close()
+
+	languageBuilder := go_stockx_client.HeaderBuilderFunc(func(ctx context.Context, url string, header http.Header) {
+		header.Set("Accept-Language", "en-GB")
+	})
+
+	client := newTestClient(t, server, go_stockx_client.WithHeader("app-version", "1"), go_stockx_client.WithHeaderBuilder(languageBuilder))
+
+	ctx := go_stockx_client.ContextWithHeader(context.Background(), "Accept-Language", "fr-FR")
+	ctx = go_stockx_client.ContextWithHeader(ctx, "app-version", "2")
+
+	if _, err := client.WithContext(ctx).GetProduct(stockxtest.DefaultProductURLKey); err != nil {
+		t.Fatalf("failed to load product: %v", err)
+	}
+
+	if _, err := client.GetProduct(stockxtest.DefaultProductURLKey); err != nil {
+		t.Fatalf("failed to load product: %v", err)
+	}
+
+	requests := server.RequestsTo("/api/products/")
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 product requests, got %d", len(requests))
+	}
+
+	if language, version := requests[0].Header.Values("Accept-Language"), requests[0].Header.Get("App-Version"); len(language) != 1 || language[0] != "fr-FR" || version != "2" {
+		t.Fatalf("expected the context headers to be sent, got %v and %q", language, version)
+	}
+
+	if language, version := requests[1].Header.Get("Accept-Language"), requests[1].Header.Get("App-Version"); language != "en-GB" || version != "1" {
+		t.Fatalf("expected the context headers to apply to the bound client only, got %q and %q", language, version)
+	}
+}
+
+type requestIDKey struct{}
+
+func TestConcurrentRequestsWithRotationAndHeaderBuilders(t *testing.T) {
+	server := stockxtest.NewServer()
+	defer server.Close()
+
+	requestID := go_stockx_client.HeaderBuilderFunc(func(ctx context.Context, url string, header http.Header) {
+		if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+			header.Add("x-request-id", id)
+		}
+	})
+
+	client := newTestClient(t, server, go_stockx_client.WithIdentities(go_stockx_client.DefaultIdentities...), go_stockx_client.WithHeaderBuilder(requestID))
+
+	const workers, calls = 8, 10
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*calls+calls)
+
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+
+		go func(worker int) {
+			defer wg.Done()
+
+			for call := 0; call < calls; call++ {
+				ctx := context.WithValue(context.Background(), requestIDKey{}, fmt.Sprintf("%d-%d", worker, call))
+
+				_, err := client.WithContext(ctx).GetProduct(stockxtest.DefaultProductURLKey)
+				if err != nil {
+					errs <- err
+				}
+			}
+		}(worker)
+	}
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		for call := 0; call < calls; call++ {
+			_, err := client.RotateIdentity()
+			if err != nil {
+				errs <- err
+			}
+		}
+	}()
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	userAgents := map[string]bool{}
+	for _, identity := range go_stockx_client.DefaultIdentities {
+		userAgents[identity.UserAgent] = true
+	}
+
+	ids := map[string]bool{}
+	for _, request := range server.RequestsTo("/api/products/") {
+		values := request.Header.Values("X-Request-Id")
+		if len(values) != 1 || ids[values[0]] {
+			t.Fatalf("expected a unique request id per request, got %v", values)
+		}
+
+		ids[values[0]] = true
+
+		if !userAgents[request.Header.Get("User-Agent")] {
+			t.Fatalf("unexpected user agent %q", request.Header.Get("User-Agent"))
+		}
+	}
+
+	if len(ids) != workers*calls {
+		t.Fatalf("expected %d product requests, got %d", workers*calls, len(ids))
+	}
+}
+
+func TestLocalizedHeaders(t *testing.T) {
+	tests := []struct {
+		currency       string
+		locale         string
+		acceptLanguage string
+		refererPath    string
+	}{
+		{currency: "USD", locale: "US", acceptLanguage: "en-US,en;q=0.9", refererPath: "/en-us"},
+		{currency: "EUR", locale: "DE", acceptLanguage: "de-DE,de;q=0.9,en-US;q=0.8,en;q=0.7", refererPath: "/de-de"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.locale, func(t *testing.T) {
+			server := stockxtest.NewServer()
+			defer server.Close()
+
+			client, err := go_stockx_client.NewClient(test.currency, test.locale, go_stockx_client.NewNoopLogger(), false, server.ClientOptions()...)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			if _, err = client.GetProduct(stockxtest.DefaultProductURLKey); err != nil {
+				t.Fatalf("failed to load product: %v", err)
+			}
+
+			requests := server.RequestsTo("/api/products/")
+			if len(requests) != 1 {
+				t.Fatalf("expected 1 product request, got %d", len(requests))
+			}
+
+			if acceptLanguage := requests[0].Header.Get("Accept-Language"); acceptLanguage != test.acceptLanguage {
+				t.Fatalf("expected accept-language %q, got %q", test.acceptLanguage, acceptLanguage)
+			}
+
+			if referer := requests[0].Header.Get("Referer"); referer != server.URL+test.refererPath {
+				t.Fatalf("expected referer %s, got %s", server.URL+test.refererPath, referer)
+			}
+		})
+	}
+}
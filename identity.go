@@ -0,0 +1,250 @@
+package go_stockx_client
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	http "github.com/bogdanfinn/fhttp"
+	"github.com/bogdanfinn/tls-client/profiles"
+)
+
+var ErrInvalidIdentity = errors.New("invalid browser identity")
+
+// BrowserIdentity bundles everything that identifies the browser the client pretends to be. TLS fingerprint,
+// user agent, client hints and header order have to describe the same browser, Validate checks that.
+type BrowserIdentity struct {
+	Name          string
+	ClientProfile profiles.ClientProfile
+	UserAgent     string
+	// SecChUa is the sec-ch-ua client hint, only chromium based browsers send it.
+	SecChUa string
+	// Platform is the operating system as written in sec-ch-ua-platform: "Windows", "macOS" or "Linux".
+	Platform string
+	// AcceptLanguage is optional and overrides the accept-language header derived from the locale of the client.
+	AcceptLanguage string
+	// HeaderOrder lists the lower case names of all sent headers in the order the browser sends them.
+	HeaderOrder []string
+}
+
+var chromeHeaderOrder = []string{
+	"sec-ch-ua",
+	"sec-ch-ua-mobile",
+	"user-agent",
+	"sec-ch-ua-platform",
+	"accept",
+	"app-platform",
+	"app-version",
+	"x-requested-with",
+	"cache-control",
+	"pragma",
+	"sec-fetch-site",
+	"sec-fetch-mode",
+	"sec-fetch-dest",
+	"referer",
+	"accept-language",
+}
+
+var firefoxHeaderOrder = []string{
+	"user-agent",
+	"accept",
+	"accept-language",
+	"app-platform",
+	"app-version",
+	"x-requested-with",
+	"referer",
+	"sec-fetch-dest",
+	"sec-fetch-mode",
+	"sec-fetch-site",
+	"pragma",
+	"cache-control",
+}
+
+var safariHeaderOrder = []string{
+	"accept",
+	"app-platform",
+	"app-version",
+	"x-requested-with",
+	"cache-control",
+	"pragma",
+	"accept-language",
+	"user-agent",
+	"referer",
+}
+
+var (
+	IdentityChrome117MacOS = BrowserIdentity{
+		Name:          "chrome-117-macos",
+		ClientProfile: profiles.Chrome_117,
+		UserAgent:     "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/117.0.0.0 Safari/537.36",
+		SecChUa:       `"Google Chrome";v="117", "Not;A=Brand";v="8", "Chromium";v="117"`,
+		Platform:      "macOS",
+		HeaderOrder:   chromeHeaderOrder,
+	}
+	IdentityChrome117Windows = BrowserIdentity{
+		Name:          "chrome-117-windows",
+		ClientProfile: profiles.Chrome_117,
+		UserAgent:     "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/117.0.0.0 Safari/537.36",
+		SecChUa:       `"Google Chrome";v="117", "Not;A=Brand";v="8", "Chromium";v="117"`,
+		Platform:      "Windows",
+		HeaderOrder:   chromeHeaderOrder,
+	}
+	IdentityChrome120MacOS = BrowserIdentity{
+		Name:          "chrome-120-macos",
+		ClientProfile: profiles.Chrome_120,
+		UserAgent:     "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+		SecChUa:       `"Not_A Brand";v="8", "Chromium";v="120", "Google Chrome";v="120"`,
+		Platform:      "macOS",
+		HeaderOrder:   chromeHeaderOrder,
+	}
+	IdentityChrome120Windows = BrowserIdentity{
+		Name:          "chrome-120-windows",
+		ClientProfile: profiles.Chrome_120,
+		UserAgent:     "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+		SecChUa:       `"Not_A Brand";v="8", "Chromium";v="120", "Google Chrome";v="120"`,
+		Platform:      "Windows",
+		HeaderOrder:   chromeHeaderOrder,
+	}
+	IdentityFirefox117Windows = BrowserIdentity{
+		Name:          "firefox-117-windows",
+		ClientProfile: profiles.Firefox_117,
+		UserAgent:     "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:109.0) Gecko/20100101 Firefox/117.0",
+		Platform:      "Windows",
+		HeaderOrder:   firefoxHeaderOrder,
+	}
+	IdentityFirefox120MacOS = BrowserIdentity{
+		Name:          "firefox-120-macos",
+		ClientProfile: profiles.Firefox_120,
+		UserAgent:     "Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:109.0) Gecko/20100101 Firefox/120.0",
+		Platform:      "macOS",
+		HeaderOrder:   firefoxHeaderOrder,
+	}
+	IdentitySafari16MacOS = BrowserIdentity{
+		Name:          "safari-16-macos",
+		ClientProfile: profiles.Safari_16_0,
+		UserAgent:     "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/16.0 Safari/605.1.15",
+		Platform:      "macOS",
+		HeaderOrder:   safariHeaderOrder,
+	}
+
+	// DefaultIdentity matches the tls profile the client always used.
+	DefaultIdentity = IdentityChrome117MacOS
+
+	DefaultIdentities = []BrowserIdentity{
+		IdentityChrome117MacOS,
+		IdentityChrome117Windows,
+		IdentityChrome120MacOS,
+		IdentityChrome120Windows,
+		IdentityFirefox117Windows,
+		IdentityFirefox120MacOS,
+		IdentitySafari16MacOS,
+	}
+)
+
+var userAgentVersionPatterns = map[string]*regexp.Regexp{
+	"Chrome":  regexp.MustCompile(`Chrome/(\d+)\.`),
+	"Firefox": regexp.MustCompile(`Firefox/(\d+)\.`),
+	"Safari":  regexp.MustCompile(`Version/(\d+)\.\d+ Safari/`),
+}
+
+var userAgentPlatforms = map[string]string{
+	"Windows": "Windows NT",
+	"macOS":   "Macintosh",
+	"Linux":   "X11; Linux",
+}
+
+// Validate checks that user agent, client hints and header order belong to the browser of the tls profile.
+func (i BrowserIdentity) Validate() error {
+	helloId := i.ClientProfile.GetClientHelloId()
+	browser := helloId.Client
+
+	pattern, ok := userAgentVersionPatterns[browser]
+	if !ok {
+		return fmt.Errorf("%w: %s: unsupported tls profile %s", ErrInvalidIdentity, i.Name, i.ClientProfile.GetClientHelloStr())
+	}
+
+	match := pattern.FindStringSubmatch(i.UserAgent)
+	if match == nil {
+		return fmt.Errorf("%w: %s: user agent %q is not a %s user agent", ErrInvalidIdentity, i.Name, i.UserAgent, browser)
+	}
+
+	profileVersion := strings.SplitN(helloId.Version, ".", 2)[0]
+	if match[1] != profileVersion {
+		return fmt.Errorf("%w: %s: user agent version %s does not match tls profile %s", ErrInvalidIdentity, i.Name, match[1], i.ClientProfile.GetClientHelloStr())
+	}
+
+	if browser == "Chrome" && !strings.Contains(i.SecChUa, fmt.Sprintf(`"Chromium";v="%s"`, profileVersion)) {
+		return fmt.Errorf("%w: %s: sec-ch-ua %q does not match tls profile %s", ErrInvalidIdentity, i.Name, i.SecChUa, i.ClientProfile.GetClientHelloStr())
+	}
+
+	if browser != "Chrome" && i.SecChUa != "" {
+		return fmt.Errorf("%w: %s: %s does not send sec-ch-ua", ErrInvalidIdentity, i.Name, browser)
+	}
+
+	platformMarker, ok := userAgentPlatforms[i.Platform]
+	if !ok || !strings.Contains(i.UserAgent, platformMarker) {
+		return fmt.Errorf("%w: %s: platform %q does not match user agent %q", ErrInvalidIdentity, i.Name, i.Platform, i.UserAgent)
+	}
+
+	order := map[string]bool{}
+	for _, key := range i.HeaderOrder {
+		order[key] = true
+	}
+
+	for key := range i.Header() {
+		if key != http.HeaderOrderKey && !order[key] {
+			return fmt.Errorf("%w: %s: header order is missing %s", ErrInvalidIdentity, i.Name, key)
+		}
+	}
+
+	return nil
+}
+
+func (i BrowserIdentity) isChromium() bool {
+	return i.SecChUa != ""
+}
+
+func (i BrowserIdentity) sendsFetchMetadata() bool {
+	return i.ClientProfile.GetClientHelloId().Client != "Safari"
+}
+
+// Header returns the request headers of this identity for the default language en-US.
+func (i BrowserIdentity) Header() http.Header {
+	return i.header(defaultLanguage, refererUrl(stockxBaseUrl, defaultLanguage))
+}
+
+func (i BrowserIdentity) header(language string, referer string) http.Header {
+	acceptLanguage := i.AcceptLanguage
+	if acceptLanguage == "" {
+		acceptLanguage = acceptLanguageHeader(language)
+	}
+
+	header := http.Header{
+		"accept":           {"application/json"},
+		"accept-language":  {acceptLanguage},
+		"app-platform":     {"Iron"},
+		"app-version":      {"2022.07.17.01"},
+		"cache-control":    {"no-cache"},
+		"pragma":           {"no-cache"},
+		"referer":          {referer},
+		"user-agent":       {i.UserAgent},
+		"x-requested-with": {"XMLHttpRequest"},
+	}
+
+	if i.isChromium() {
+		header["sec-ch-ua"] = []string{i.SecChUa}
+		header["sec-ch-ua-mobile"] = []string{"?0"}
+		header["sec-ch-ua-platform"] = []string{fmt.Sprintf("%q", i.Platform)}
+	}
+
+	if i.sendsFetchMetadata() {
+		header["sec-fetch-dest"] = []string{"empty"}
+		header["sec-fetch-mode"] = []string{"cors"}
+		header["sec-fetch-site"] = []string{"same-origin"}
+	}
+
+	header[http.HeaderOrderKey] = append([]string(nil), i.HeaderOrder...)
+
+	return header
+}
@@ -0,0 +1,162 @@
+package go_stockx_client_test
+
+import (
+	"errors"
+	"testing"
+
+	go_stockx_client "github.com/bogdanfinn/go-stockx-client"
+	"github.com/bogdanfinn/go-stockx-client/stockxtest"
+	"github.com/bogdanfinn/tls-client/profiles"
+)
+
+func TestValidateIdentity(t *testing.T) {
+	for _, identity := range go_stockx_client.DefaultIdentities {
+		if err := identity.Validate(); err != nil {
+			t.Errorf("expected %s to be valid, got %v", identity.Name, err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(identity *go_stockx_client.BrowserIdentity)
+	}{
+		{
+			name: "empty profile",
+			modify: func(identity *go_stockx_client.BrowserIdentity) {
+				identity.ClientProfile = profiles.ClientProfile{}
+			},
+		},
+		{
+			name: "user agent of another browser",
+			modify: func(identity *go_stockx_client.BrowserIdentity) {
+				identity.UserAgent = go_stockx_client.IdentityFirefox117Windows.UserAgent
+			},
+		},
+		{
+			name: "user agent version does not match the profile",
+			modify: func(identity *go_stockx_client.BrowserIdentity) {
+				identity.UserAgent = go_stockx_client.IdentityChrome120MacOS.UserAgent
+				identity.SecChUa = go_stockx_client.IdentityChrome120MacOS.SecChUa
+			},
+		},
+		{
+			name: "sec-ch-ua does not match the user agent",
+			modify: func(identity *go_stockx_client.BrowserIdentity) {
+				identity.SecChUa = go_stockx_client.IdentityChrome120MacOS.SecChUa
+			},
+		},
+		{
+			name: "platform does not match the user agent",
+			modify: func(identity *go_stockx_client.BrowserIdentity) {
+				identity.Platform = "Windows"
+			},
+		},
+		{
+			name: "unknown platform",
+			modify: func(identity *go_stockx_client.BrowserIdentity) {
+				identity.Platform = "Android"
+			},
+		},
+		{
+			name: "incomplete header order",
+			modify: func(identity *go_stockx_client.BrowserIdentity) {
+				identity.HeaderOrder = []string{"user-agent", "accept"}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			identity := go_stockx_client.IdentityChrome117MacOS
+			test.modify(&identity)
+
+			if err := identity.Validate(); !errors.Is(err, go_stockx_client.ErrInvalidIdentity) {
+				t.Fatalf("expected ErrInvalidIdentity, got %v", err)
+			}
+		})
+	}
+
+	firefox := go_stockx_client.IdentityFirefox117Windows
+	firefox.SecChUa = go_stockx_client.IdentityChrome117MacOS.SecChUa
+
+	if err := firefox.Validate(); !errors.Is(err, go_stockx_client.ErrInvalidIdentity) {
+		t.Fatalf("expected sec-ch-ua to be rejected for firefox, got %v", err)
+	}
+}
+
+func TestNewClientRejectsInvalidIdentity(t *testing.T) {
+	identity := go_stockx_client.IdentityChrome117MacOS
+	identity.Platform = "Windows"
+
+	_, err := go_stockx_client.NewClient("EUR", "DE", go_stockx_client.NewNoopLogger(), false, go_stockx_client.WithIdentities(go_stockx_client.IdentityFirefox117Windows, identity))
+	if !errors.Is(err, go_stockx_client.ErrInvalidIdentity) {
+		t.Fatalf("expected ErrInvalidIdentity, got %v", err)
+	}
+}
+
+// rejectingTransport refuses to switch to the identity named reject, like a transport without support for its tls profile.
+type rejectingTransport struct {
+	go_stockx_client.Transport
+	reject string
+}
+
+func (t *rejectingTransport) SetIdentity(identity go_stockx_client.BrowserIdentity) error {
+	if identity.Name == t.reject {
+		return errors.New("unsupported tls profile")
+	}
+
+	return nil
+}
+
+func TestRotateIdentityKeepsIdentityOnError(t *testing.T) {
+	server := stockxtest.NewServer()
+	defer server.Close()
+
+	identities := []go_stockx_client.BrowserIdentity{go_stockx_client.IdentityChrome117MacOS, go_stockx_client.IdentityFirefox117Windows}
+
+	transport := &rejectingTransport{Transport: go_stockx_client.NewNetHttpTransport(0)}
+	client := newTestClient(t, server, go_stockx_client.WithIdentities(identities...), go_stockx_client.WithTransport(transport))
+
+	// the pool is copied, changing it afterwards does not reach the client
+	identities[0].UserAgent = "broken"
+	identities[1].UserAgent = "broken"
+
+	// the pool starts at a random identity, the transport rejects the other one
+	current := client.GetIdentity()
+	if current.Validate() != nil {
+		t.Fatalf("expected the client to keep its own copy of the pool, got %+v", current)
+	}
+
+	transport.reject = go_stockx_client.IdentityFirefox117Windows.Name
+	if current.Name == transport.reject {
+		transport.reject = go_stockx_client.IdentityChrome117MacOS.Name
+	}
+
+	if _, err := client.GetProduct(stockxtest.DefaultProductURLKey); err != nil {
+		t.Fatalf("failed to load product: %v", err)
+	}
+
+	identity, err := client.RotateIdentity()
+	if err == nil {
+		t.Fatalf("expected the rotation to fail")
+	}
+
+	if identity.Name != current.Name || client.GetIdentity().Name != current.Name {
+		t.Fatalf("expected to keep identity %s, got %s and %s", current.Name, identity.Name, client.GetIdentity().Name)
+	}
+
+	server.Reset()
+
+	if _, err = client.GetProduct(stockxtest.DefaultProductURLKey); err != nil {
+		t.Fatalf("failed to load product after the failed rotation: %v", err)
+	}
+
+	requests := server.Requests()
+	if len(requests) != 1 || requests[0].Header.Get("User-Agent") != current.UserAgent {
+		t.Fatalf("expected the session to be kept with the user agent of %s, got %+v", current.Name, requests)
+	}
+
+	if requests[0].Header.Get("sec-ch-ua") != current.SecChUa {
+		t.Fatalf("expected the client hints of %s to be kept, got %q", current.Name, requests[0].Header.Get("sec-ch-ua"))
+	}
+}
@@ -0,0 +1,64 @@
+package go_stockx_client
+
+import (
+	"strings"
+)
+
+const defaultLanguage = "en-US"
+
+// countryLanguages maps the country of the client to the language stockx serves its site in for this country.
+var countryLanguages = map[string]string{
+	"US": "en-US",
+	"CA": "en-US",
+	"AU": "en-US",
+	"GB": "en-GB",
+	"UK": "en-GB",
+	"IE": "en-GB",
+	"DE": "de-DE",
+	"AT": "de-DE",
+	"CH": "de-DE",
+	"FR": "fr-FR",
+	"BE": "fr-FR",
+	"IT": "it-IT",
+	"ES": "es-ES",
+	"NL": "nl-NL",
+	"JP": "ja-JP",
+	"KR": "ko-KR",
+	"CN": "zh-CN",
+	"HK": "zh-CN",
+}
+
+// LanguageForCountry returns the language tag (e.g. "en-US") stockx uses for a country code, en-US for unknown countries.
+func LanguageForCountry(country string) string {
+	if language, ok := countryLanguages[strings.ToUpper(country)]; ok {
+		return language
+	}
+
+	return defaultLanguage
+}
+
+// acceptLanguageHeader builds the accept-language value a browser configured for language sends,
+// with english as fallback like most browsers are set up.
+func acceptLanguageHeader(language string) string {
+	parts := strings.SplitN(language, "-", 2)
+	primary := strings.ToLower(parts[0])
+
+	values := []string{language}
+	if len(parts) == 2 {
+		values = append(values, primary+";q=0.9")
+	}
+
+	switch {
+	case primary != "en":
+		values = append(values, "en-US;q=0.8", "en;q=0.7")
+	case language != "en-US":
+		values = append(values, "en-US;q=0.8")
+	}
+
+	return strings.Join(values, ",")
+}
+
+// refererUrl returns the localized stockx start page, e.g. https://stockx.com/en-us for en-US.
+func refererUrl(baseUrl string, language string) string {
+	return strings.TrimSuffix(baseUrl, "/") + "/" + strings.ToLower(language)
+}
@@ -0,0 +1,65 @@
+package go_stockx_client
+
+import "testing"
+
+func TestLanguageForCountry(t *testing.T) {
+	tests := []struct {
+		country  string
+		language string
+	}{
+		{country: "US", language: "en-US"},
+		{country: "us", language: "en-US"},
+		{country: "DE", language: "de-DE"},
+		{country: "at", language: "de-DE"},
+		{country: "GB", language: "en-GB"},
+		{country: "UK", language: "en-GB"},
+		{country: "FR", language: "fr-FR"},
+		{country: "JP", language: "ja-JP"},
+		{country: "BR", language: "en-US"},
+		{country: "", language: "en-US"},
+	}
+
+	for _, test := range tests {
+		if language := LanguageForCountry(test.country); language != test.language {
+			t.Errorf("expected %s for %q, got %s", test.language, test.country, language)
+		}
+	}
+}
+
+func TestAcceptLanguageHeader(t *testing.T) {
+	tests := []struct {
+		language string
+		header   string
+	}{
+		{language: "en-US", header: "en-US,en;q=0.9"},
+		{language: "en-GB", header: "en-GB,en;q=0.9,en-US;q=0.8"},
+		{language: "de-DE", header: "de-DE,de;q=0.9,en-US;q=0.8,en;q=0.7"},
+		{language: "ja-JP", header: "ja-JP,ja;q=0.9,en-US;q=0.8,en;q=0.7"},
+		{language: "en", header: "en,en-US;q=0.8"},
+		{language: "fr", header: "fr,en-US;q=0.8,en;q=0.7"},
+	}
+
+	for _, test := range tests {
+		if header := acceptLanguageHeader(test.language); header != test.header {
+			t.Errorf("expected %q for %s, got %q", test.header, test.language, header)
+		}
+	}
+}
+
+func TestRefererUrl(t *testing.T) {
+	tests := []struct {
+		baseUrl  string
+		language string
+		referer  string
+	}{
+		{baseUrl: "https://stockx.com", language: "en-US", referer: "https://stockx.com/en-us"},
+		{baseUrl: "https://stockx.com/", language: "de-DE", referer: "https://stockx.com/de-de"},
+		{baseUrl: "http://127.0.0.1:8080", language: "en-GB", referer: "http://127.0.0.1:8080/en-gb"},
+	}
+
+	for _, test := range tests {
+		if referer := refererUrl(test.baseUrl, test.language); referer != test.referer {
+			t.Errorf("expected %s for %s and %s, got %s", test.referer, test.baseUrl, test.language, referer)
+		}
+	}
+}
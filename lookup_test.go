@@ -0,0 +1,109 @@
+package go_stockx_client_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	go_stockx_client "github.com/bogdanfinn/go-stockx-client"
+	"github.com/bogdanfinn/go-stockx-client/stockxtest"
+)
+
+// staleSearchFixture lists a product which no longer exists in front of the default product.
+const staleSearchFixture = `{
+	"Pagination": {"query": "", "limit": "10", "page": 1, "total": 2},
+	"Products": [
+		{"brand": "Nike", "title": "Removed Product", "urlKey": "removed-product", "objectID": "removed-product"},
+		{"brand": "Nike", "title": "Nike Dunk Low Retro White Black Panda", "urlKey": "nike-dunk-low-retro-white-black-2021", "objectID": "5e6a1e57-1c7d-435a-82bd-5666a13560fe"}
+	]
+}`
+
+func newTestClient(t *testing.T, server *stockxtest.Server, options ...go_stockx_client.ClientOption) go_stockx_client.Client {
+	t.Helper()
+
+	client, err := go_stockx_client.NewClient("EUR", "DE", go_stockx_client.NewNoopLogger(), false, append(server.ClientOptions(), options...)...)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	return client
+}
+
+func TestGetProductByStyleIDSkipsMissingCandidates(t *testing.T) {
+	server := stockxtest.NewServer()
+	defer server.Close()
+
+	server.SetDefaultSearch([]byte(staleSearchFixture))
+
+	product, err := newTestClient(t, server).GetProductByStyleID(stockxtest.DefaultProductStyleID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if product.UUID != stockxtest.DefaultProductUUID {
+		t.Fatalf("expected the default product, got %s", product.UUID)
+	}
+
+	if len(server.RequestsTo("/api/products/removed-product")) != 1 {
+		t.Fatalf("expected the stale candidate to be requested once")
+	}
+}
+
+func TestGetProductByGTINSkipsMissingCandidates(t *testing.T) {
+	server := stockxtest.NewServer()
+	defer server.Close()
+
+	server.SetDefaultSearch([]byte(staleSearchFixture))
+
+	product, variant, err := newTestClient(t, server).GetProductByGTIN("0194502876017")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if product.UUID != stockxtest.DefaultProductUUID {
+		t.Fatalf("expected the default product, got %s", product.UUID)
+	}
+
+	if variant.Size != "10" {
+		t.Fatalf("expected size 10, got %s", variant.Size)
+	}
+}
+
+func TestGetProductByGTINReturnsListedVariant(t *testing.T) {
+	server := stockxtest.NewServer()
+	defer server.Close()
+
+	// a size which was never sold has no last sale size, its size comes from shoeSize
+	fixture := map[string]map[string]interface{}{}
+	if err := json.Unmarshal(stockxtest.DefaultProductFixture(), &fixture); err != nil {
+		t.Fatalf("failed to decode product fixture: %v", err)
+	}
+
+	child := fixture["Product"]["children"].(map[string]interface{})["2b1fb7d8-6b9c-4b4d-8d9f-6c8b2c1e7a02"].(map[string]interface{})
+	child["market"].(map[string]interface{})["lastSaleSize"] = ""
+
+	body, err := json.Marshal(fixture)
+	if err != nil {
+		t.Fatalf("failed to encode product fixture: %v", err)
+	}
+
+	if err = server.AddProduct(body); err != nil {
+		t.Fatalf("failed to add product: %v", err)
+	}
+
+	product, variant, err := newTestClient(t, server).GetProductByGTIN("194502876017")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if variant.Size != "10" {
+		t.Fatalf("expected size 10 from shoeSize, got %q", variant.Size)
+	}
+
+	for _, productVariant := range product.Variants {
+		if productVariant.UUID == variant.UUID && productVariant.Size == variant.Size {
+			return
+		}
+	}
+
+	t.Fatalf("expected variant %s to be listed in the product variants %+v", variant.UUID, product.Variants)
+}
@@ -0,0 +1,42 @@
+package go_stockx_client
+
+import (
+	"time"
+)
+
+// Metrics receives measurements of the client, see the prometheus subpackage for a ready to use implementation.
+// Implementations have to be safe for concurrent use. Endpoints are the names used in log messages and schema
+// reports, e.g. "search" or "product details".
+type Metrics interface {
+	// ObserveRequest is called for every http request including retries, statusCode is 0 if no response was received.
+	ObserveRequest(endpoint string, statusCode int, duration time.Duration)
+	// ObserveOperation is called for every call of a public client method like "GetProduct".
+	ObserveOperation(operation string, duration time.Duration, err error)
+	// IncRetries is called whenever a request is sent again after a challenge.
+	IncRetries(endpoint string)
+	// IncDecodeFailures is called when a response can not be decoded or fails the strict schema check.
+	IncDecodeFailures(endpoint string)
+	// IncProxyFailures is called when a request through a proxy fails without response or with 407.
+	IncProxyFailures(endpoint string)
+	// IncCacheHits is called when a response is served from a cassette (CassetteModeReplay) instead of the network.
+	IncCacheHits(endpoint string)
+}
+
+type noopMetrics struct{}
+
+func (noopMetrics) ObserveRequest(endpoint string, statusCode int, duration time.Duration) {}
+
+func (noopMetrics) ObserveOperation(operation string, duration time.Duration, err error) {}
+
+func (noopMetrics) IncRetries(endpoint string) {}
+
+func (noopMetrics) IncDecodeFailures(endpoint string) {}
+
+func (noopMetrics) IncProxyFailures(endpoint string) {}
+
+func (noopMetrics) IncCacheHits(endpoint string) {}
+
+// observeOperation reports a public method call, defer it with a pointer to the named error result.
+func (c *client) observeOperation(operation string, start time.Time, err *error) {
+	c.metrics.ObserveOperation(operation, time.Since(start), *err)
+}
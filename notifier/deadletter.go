@@ -0,0 +1,59 @@
+package notifier
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+type deadLetter struct {
+	URL      string          `json:"url"`
+	Payload  json.RawMessage `json:"payload"`
+	Error    string          `json:"error"`
+	Attempts int             `json:"attempts"`
+	FailedAt time.Time       `json:"failedAt"`
+}
+
+type deadLetterWriter struct {
+	sync.Mutex
+	path string
+}
+
+func newDeadLetterWriter(path string) *deadLetterWriter {
+	return &deadLetterWriter{path: path}
+}
+
+func (w *deadLetterWriter) write(entry deadLetter) error {
+	if !json.Valid(entry.Payload) {
+		encoded, err := json.Marshal(string(entry.Payload))
+		if err != nil {
+			return fmt.Errorf("failed to encode dead letter payload: %w", err)
+		}
+
+		entry.Payload = encoded
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode dead letter: %w", err)
+	}
+
+	w.Lock()
+	defer w.Unlock()
+
+	file, err := os.OpenFile(w.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open dead letter file: %w", err)
+	}
+
+	defer file.Close()
+
+	_, err = file.Write(append(line, '\n'))
+	if err != nil {
+		return fmt.Errorf("failed to write dead letter file: %w", err)
+	}
+
+	return nil
+}
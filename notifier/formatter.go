@@ -0,0 +1,232 @@
+package notifier
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const stockxProductUrlTemplate = "https://stockx.com/%s"
+
+type Formatter interface {
+	Format(event Event) ([]byte, error)
+}
+
+type FormatterFunc func(event Event) ([]byte, error)
+
+func (f FormatterFunc) Format(event Event) ([]byte, error) {
+	return f(event)
+}
+
+type jsonFormatter struct {
+}
+
+func NewJSONFormatter() Formatter {
+	return &jsonFormatter{}
+}
+
+func (f jsonFormatter) Format(event Event) ([]byte, error) {
+	return json.Marshal(event)
+}
+
+type discordFormatter struct {
+	username string
+}
+
+// NewDiscordFormatter renders events as a Discord webhook payload with a single embed.
+func NewDiscordFormatter(username string) Formatter {
+	return &discordFormatter{username: username}
+}
+
+type discordPayload struct {
+	Username string         `json:"username,omitempty"`
+	Content  string         `json:"content,omitempty"`
+	Embeds   []discordEmbed `json:"embeds"`
+}
+
+type discordEmbed struct {
+	Title       string              `json:"title"`
+	URL         string              `json:"url,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Color       int                 `json:"color"`
+	Timestamp   string              `json:"timestamp"`
+	Thumbnail   *discordEmbedImage  `json:"thumbnail,omitempty"`
+	Fields      []discordEmbedField `json:"fields,omitempty"`
+}
+
+type discordEmbedImage struct {
+	URL string `json:"url"`
+}
+
+type discordEmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+func (f discordFormatter) Format(event Event) ([]byte, error) {
+	embed := discordEmbed{
+		Title:       eventTitle(event),
+		URL:         productUrl(event),
+		Description: event.Message,
+		Color:       eventColor(event),
+		Timestamp:   event.OccurredAt.UTC().Format(time.RFC3339),
+	}
+
+	if event.Product != nil && event.Product.Thumburl != "" {
+		embed.Thumbnail = &discordEmbedImage{URL: event.Product.Thumburl}
+	}
+
+	for _, field := range eventFields(event) {
+		embed.Fields = append(embed.Fields, discordEmbedField{
+			Name:   field[0],
+			Value:  field[1],
+			Inline: true,
+		})
+	}
+
+	return json.Marshal(discordPayload{
+		Username: f.username,
+		Embeds:   []discordEmbed{embed},
+	})
+}
+
+type slackFormatter struct {
+}
+
+// NewSlackFormatter renders events as a Slack incoming webhook payload using block kit sections.
+func NewSlackFormatter() Formatter {
+	return &slackFormatter{}
+}
+
+type slackPayload struct {
+	Text   string       `json:"text"`
+	Blocks []slackBlock `json:"blocks"`
+}
+
+type slackBlock struct {
+	Type      string      `json:"type"`
+	Text      *slackText  `json:"text,omitempty"`
+	Fields    []slackText `json:"fields,omitempty"`
+	Accessory *slackImage `json:"accessory,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type slackImage struct {
+	Type     string `json:"type"`
+	ImageUrl string `json:"image_url"`
+	AltText  string `json:"alt_text"`
+}
+
+func (f slackFormatter) Format(event Event) ([]byte, error) {
+	title := eventTitle(event)
+
+	headline := fmt.Sprintf("*%s*", title)
+	if url := productUrl(event); url != "" {
+		headline = fmt.Sprintf("*<%s|%s>*", url, title)
+	}
+
+	if event.Message != "" {
+		headline = fmt.Sprintf("%s\n%s", headline, event.Message)
+	}
+
+	section := slackBlock{
+		Type: "section",
+		Text: &slackText{Type: "mrkdwn", Text: headline},
+	}
+
+	if event.Product != nil && event.Product.Thumburl != "" {
+		section.Accessory = &slackImage{
+			Type:     "image",
+			ImageUrl: event.Product.Thumburl,
+			AltText:  title,
+		}
+	}
+
+	blocks := []slackBlock{section}
+
+	var fields []slackText
+	for _, field := range eventFields(event) {
+		fields = append(fields, slackText{
+			Type: "mrkdwn",
+			Text: fmt.Sprintf("*%s*\n%s", field[0], field[1]),
+		})
+	}
+
+	if len(fields) > 0 {
+		blocks = append(blocks, slackBlock{Type: "section", Fields: fields})
+	}
+
+	return json.Marshal(slackPayload{
+		Text:   title,
+		Blocks: blocks,
+	})
+}
+
+func eventTitle(event Event) string {
+	name := "Unknown product"
+	if event.Product != nil {
+		name = event.Product.Title
+	}
+
+	if event.Variant != nil && event.Variant.Size != "" {
+		name = fmt.Sprintf("%s (Size %s)", name, event.Variant.Size)
+	}
+
+	switch event.Type {
+	case EventTypePriceChange:
+		return fmt.Sprintf("Price change: %s", name)
+	case EventTypeAlert:
+		return fmt.Sprintf("Alert: %s", name)
+	default:
+		return fmt.Sprintf("%s: %s", event.Type, name)
+	}
+}
+
+func eventColor(event Event) int {
+	if event.Type != EventTypePriceChange || event.PreviousPrice == 0 {
+		return 0x5865f2
+	}
+
+	if event.CurrentPrice < event.PreviousPrice {
+		return 0x08a05c
+	}
+
+	return 0xe03131
+}
+
+func eventFields(event Event) [][2]string {
+	var fields [][2]string
+
+	if event.Type == EventTypePriceChange {
+		fields = append(fields, [2]string{"Previous Price", fmt.Sprintf("%d", event.PreviousPrice)})
+		fields = append(fields, [2]string{"Current Price", fmt.Sprintf("%d", event.CurrentPrice)})
+	}
+
+	if event.Variant != nil {
+		fields = append(fields, [2]string{"Lowest Ask", fmt.Sprintf("%d", event.Variant.Lowestask)})
+		fields = append(fields, [2]string{"Highest Bid", fmt.Sprintf("%d", event.Variant.Highestbid)})
+		fields = append(fields, [2]string{"Last Sale", fmt.Sprintf("%d", event.Variant.Lastsale)})
+	} else if event.Product != nil {
+		fields = append(fields, [2]string{"Lowest Ask", fmt.Sprintf("%d", event.Product.Lowestask)})
+		fields = append(fields, [2]string{"Highest Bid", fmt.Sprintf("%d", event.Product.Highestbid)})
+	}
+
+	if event.Product != nil && event.Product.Styleid != "" {
+		fields = append(fields, [2]string{"Style ID", event.Product.Styleid})
+	}
+
+	return fields
+}
+
+func productUrl(event Event) string {
+	if event.Product == nil || event.Product.ProductIdentifier == "" {
+		return ""
+	}
+
+	return fmt.Sprintf(stockxProductUrlTemplate, event.Product.ProductIdentifier)
+}
@@ -0,0 +1,92 @@
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	go_stockx_client "github.com/bogdanfinn/go-stockx-client"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata/golden")
+
+func formatterTestProduct() (*go_stockx_client.ProductDetails, *go_stockx_client.ProductDetailsVariant) {
+	product := &go_stockx_client.ProductDetails{
+		Title:             "Nike Dunk Low Retro White Black Panda",
+		ProductIdentifier: "nike-dunk-low-retro-white-black-2021",
+		Styleid:           "DD1391-100",
+		Thumburl:          "https://images.stockx.com/images/Nike-Dunk-Low-Retro-White-Black-2021-Product.jpg?w=140",
+		Lowestask:         115,
+		Highestbid:        105,
+	}
+
+	variant := &go_stockx_client.ProductDetailsVariant{
+		Size:       "10",
+		Lowestask:  115,
+		Highestbid: 105,
+		Lastsale:   118,
+	}
+
+	return product, variant
+}
+
+// The payloads are compared with testdata/golden, run "go test ./notifier -run TestFormatterGolden -update"
+// after intended changes.
+func TestFormatterGolden(t *testing.T) {
+	product, variant := formatterTestProduct()
+	occurredAt := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	priceDrop := NewPriceChangeEvent(product, variant, 130, 115)
+	priceDrop.OccurredAt = occurredAt
+
+	alert := NewAlertEvent(product, nil, "lowest ask below retail")
+	alert.OccurredAt = occurredAt
+
+	tests := []struct {
+		name      string
+		formatter Formatter
+		event     Event
+	}{
+		{name: "discord-price-change", formatter: NewDiscordFormatter("stockx"), event: priceDrop},
+		{name: "discord-alert", formatter: NewDiscordFormatter(""), event: alert},
+		{name: "slack-price-change", formatter: NewSlackFormatter(), event: priceDrop},
+		{name: "slack-alert", formatter: NewSlackFormatter(), event: alert},
+		{name: "slack-without-product", formatter: NewSlackFormatter(), event: Event{Type: EventTypeAlert, Message: "watchlist empty", OccurredAt: occurredAt}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			payload, err := test.formatter.Format(test.event)
+			if err != nil {
+				t.Fatalf("failed to format event: %v", err)
+			}
+
+			var actual bytes.Buffer
+			if err = json.Indent(&actual, payload, "", "  "); err != nil {
+				t.Fatalf("formatter returned invalid json: %v", err)
+			}
+
+			actual.WriteByte('\n')
+			goldenPath := filepath.Join("testdata", "golden", test.name+".json")
+
+			if *updateGolden {
+				if err = ioutil.WriteFile(goldenPath, actual.Bytes(), 0o644); err != nil {
+					t.Fatalf("failed to update golden file: %v", err)
+				}
+			}
+
+			expected, err := ioutil.ReadFile(goldenPath)
+			if err != nil {
+				t.Fatalf("failed to read golden file: %v", err)
+			}
+
+			if !bytes.Equal(expected, actual.Bytes()) {
+				t.Errorf("%s payload differs from %s:\n%s", test.name, goldenPath, actual.Bytes())
+			}
+		})
+	}
+}
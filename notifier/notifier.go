@@ -0,0 +1,51 @@
+package notifier
+
+import (
+	"context"
+	"time"
+
+	go_stockx_client "github.com/bogdanfinn/go-stockx-client"
+)
+
+type EventType string
+
+const (
+	EventTypePriceChange EventType = "price_change"
+	EventTypeAlert       EventType = "alert"
+)
+
+type Notifier interface {
+	Notify(event Event) error
+	NotifyContext(ctx context.Context, event Event) error
+}
+
+type Event struct {
+	Type          EventType                               `json:"type"`
+	Message       string                                  `json:"message,omitempty"`
+	Product       *go_stockx_client.ProductDetails        `json:"product,omitempty"`
+	Variant       *go_stockx_client.ProductDetailsVariant `json:"variant,omitempty"`
+	PreviousPrice int                                     `json:"previousPrice,omitempty"`
+	CurrentPrice  int                                     `json:"currentPrice,omitempty"`
+	OccurredAt    time.Time                               `json:"occurredAt"`
+}
+
+func NewPriceChangeEvent(product *go_stockx_client.ProductDetails, variant *go_stockx_client.ProductDetailsVariant, previousPrice int, currentPrice int) Event {
+	return Event{
+		Type:          EventTypePriceChange,
+		Product:       product,
+		Variant:       variant,
+		PreviousPrice: previousPrice,
+		CurrentPrice:  currentPrice,
+		OccurredAt:    time.Now(),
+	}
+}
+
+func NewAlertEvent(product *go_stockx_client.ProductDetails, variant *go_stockx_client.ProductDetailsVariant, message string) Event {
+	return Event{
+		Type:       EventTypeAlert,
+		Message:    message,
+		Product:    product,
+		Variant:    variant,
+		OccurredAt: time.Now(),
+	}
+}
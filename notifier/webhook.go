@@ -0,0 +1,305 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	go_stockx_client "github.com/bogdanfinn/go-stockx-client"
+)
+
+const (
+	SignatureHeader = "X-Stockx-Signature"
+	TimestampHeader = "X-Stockx-Timestamp"
+)
+
+type WebhookOption func(config *webhookConfig)
+
+type webhookConfig struct {
+	secret         string
+	formatter      Formatter
+	httpClient     *http.Client
+	maxRetries     int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	deadLetterFile string
+}
+
+// WithSecret enables HMAC-SHA256 signing of every payload. Receivers verify the
+// signature by computing hex(hmac(secret, timestamp + "." + body)).
+func WithSecret(secret string) WebhookOption {
+	return func(config *webhookConfig) {
+		config.secret = secret
+	}
+}
+
+func WithFormatter(formatter Formatter) WebhookOption {
+	return func(config *webhookConfig) {
+		config.formatter = formatter
+	}
+}
+
+func WithHttpClient(httpClient *http.Client) WebhookOption {
+	return func(config *webhookConfig) {
+		config.httpClient = httpClient
+	}
+}
+
+// WithRetries sets how often a failed delivery is retried, negative values disable retries.
+func WithRetries(maxRetries int, initialBackoff time.Duration, maxBackoff time.Duration) WebhookOption {
+	return func(config *webhookConfig) {
+		if maxRetries < 0 {
+			maxRetries = 0
+		}
+
+		config.maxRetries = maxRetries
+		config.initialBackoff = initialBackoff
+		config.maxBackoff = maxBackoff
+	}
+}
+
+// WithDeadLetterFile appends every delivery which failed after all retries as a json line to the given file.
+// Deliveries aborted by canceling the context of NotifyContext are not written.
+func WithDeadLetterFile(path string) WebhookOption {
+	return func(config *webhookConfig) {
+		config.deadLetterFile = path
+	}
+}
+
+type webhookNotifier struct {
+	urls       []string
+	logger     go_stockx_client.Logger
+	config     webhookConfig
+	deadLetter *deadLetterWriter
+}
+
+func NewWebhookNotifier(urls []string, logger go_stockx_client.Logger, options ...WebhookOption) Notifier {
+	config := webhookConfig{
+		formatter:      NewJSONFormatter(),
+		httpClient:     &http.Client{Timeout: 10 * time.Second},
+		maxRetries:     3,
+		initialBackoff: 500 * time.Millisecond,
+		maxBackoff:     30 * time.Second,
+	}
+
+	for _, option := range options {
+		option(&config)
+	}
+
+	if config.maxRetries < 0 {
+		config.maxRetries = 0
+	}
+
+	var deadLetter *deadLetterWriter
+	if config.deadLetterFile != "" {
+		deadLetter = newDeadLetterWriter(config.deadLetterFile)
+	}
+
+	return &webhookNotifier{
+		urls:       urls,
+		logger:     logger,
+		config:     config,
+		deadLetter: deadLetter,
+	}
+}
+
+func (n *webhookNotifier) Notify(event Event) error {
+	return n.NotifyContext(context.Background(), event)
+}
+
+// NotifyContext delivers the event to all webhooks, canceling ctx aborts pending requests and retry backoffs.
+// Aborted deliveries return an error but are not written to the dead letter file.
+func (n *webhookNotifier) NotifyContext(ctx context.Context, event Event) error {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	payload, err := n.config.formatter.Format(event)
+	if err != nil {
+		return fmt.Errorf("failed to format webhook payload: %w", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(n.urls))
+
+	for i, url := range n.urls {
+		wg.Add(1)
+		go func(i int, url string) {
+			defer wg.Done()
+			errs[i] = n.deliver(ctx, url, payload)
+		}(i, url)
+	}
+
+	wg.Wait()
+
+	failed := 0
+	var firstErr error
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		failed++
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to deliver event to %d of %d webhooks: %w", failed, len(n.urls), firstErr)
+	}
+
+	return nil
+}
+
+func (n *webhookNotifier) deliver(ctx context.Context, url string, payload []byte) error {
+	backoff := n.config.initialBackoff
+
+	var err error
+	attempts := 0
+
+	for attempts <= n.config.maxRetries {
+		attempts++
+
+		var retryAfter time.Duration
+		var retryable bool
+		retryAfter, retryable, err = n.post(ctx, url, payload)
+
+		if err == nil {
+			return nil
+		}
+
+		n.logger.Warn("webhook delivery to %s failed (attempt %d of %d): %s", url, attempts, n.config.maxRetries+1, err.Error())
+
+		if !retryable || attempts > n.config.maxRetries {
+			break
+		}
+
+		wait := backoff
+		if retryAfter > wait {
+			wait = retryAfter
+		}
+
+		if n.config.maxBackoff > 0 && wait > n.config.maxBackoff {
+			wait = n.config.maxBackoff
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			err = fmt.Errorf("webhook delivery canceled: %w", ctx.Err())
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+
+		backoff = n.nextBackoff(backoff)
+	}
+
+	// canceled deliveries did not fail, the caller gave up on them
+	if n.deadLetter != nil && ctx.Err() == nil {
+		dlErr := n.deadLetter.write(deadLetter{
+			URL:      url,
+			Payload:  payload,
+			Error:    err.Error(),
+			Attempts: attempts,
+			FailedAt: time.Now(),
+		})
+
+		if dlErr != nil {
+			n.logger.Error("failed to write webhook delivery to dead letter file: %s", dlErr.Error())
+		}
+	}
+
+	return err
+}
+
+// nextBackoff doubles backoff, limited to the configured maximum and without overflowing.
+func (n *webhookNotifier) nextBackoff(backoff time.Duration) time.Duration {
+	next := backoff * 2
+	if next < backoff {
+		next = backoff
+	}
+
+	if n.config.maxBackoff > 0 && next > n.config.maxBackoff {
+		next = n.config.maxBackoff
+	}
+
+	return next
+}
+
+func (n *webhookNotifier) post(ctx context.Context, url string, payload []byte) (time.Duration, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if n.config.secret != "" {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(TimestampHeader, timestamp)
+		req.Header.Set(SignatureHeader, "sha256="+Sign(n.config.secret, timestamp, payload))
+	}
+
+	resp, err := n.config.httpClient.Do(req)
+	if err != nil {
+		return 0, true, fmt.Errorf("failed to send webhook request: %w", err)
+	}
+
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return 0, false, nil
+	}
+
+	retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+
+	return parseRetryAfter(resp.Header.Get("Retry-After")), retryable, fmt.Errorf("received wrong status code during webhook delivery: %d", resp.StatusCode)
+}
+
+// Sign returns the hex encoded HMAC-SHA256 signature the notifier sends in the SignatureHeader.
+func Sign(secret string, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a signature header value as sent by the notifier.
+func Verify(secret string, timestamp string, payload []byte, signature string) bool {
+	expected := "sha256=" + Sign(secret, timestamp, payload)
+
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date)
+	}
+
+	return 0
+}
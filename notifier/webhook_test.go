@@ -0,0 +1,243 @@
+package notifier
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	go_stockx_client "github.com/bogdanfinn/go-stockx-client"
+)
+
+func testEvent() Event {
+	return Event{
+		Type:       EventTypeAlert,
+		Message:    "price dropped",
+		OccurredAt: time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestWebhookSignsPayload(t *testing.T) {
+	const secret = "top-secret"
+
+	var verified int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+
+		if Verify(secret, r.Header.Get(TimestampHeader), body, r.Header.Get(SignatureHeader)) {
+			atomic.AddInt32(&verified, 1)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier([]string{server.URL}, go_stockx_client.NewNoopLogger(), WithSecret(secret))
+
+	if err := notifier.Notify(testEvent()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if atomic.LoadInt32(&verified) != 1 {
+		t.Fatalf("expected a verifiable signature")
+	}
+
+	if Verify("wrong-secret", "1", []byte("{}"), "sha256="+Sign(secret, "1", []byte("{}"))) {
+		t.Fatalf("expected signature of another secret to be rejected")
+	}
+}
+
+func TestWebhookRetriesServerErrors(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier([]string{server.URL}, go_stockx_client.NewNoopLogger(), WithRetries(3, time.Millisecond, 5*time.Millisecond))
+
+	if err := notifier.Notify(testEvent()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Fatalf("expected 3 attempts, got %d", got)
+	}
+}
+
+func TestWebhookDoesNotRetryClientErrors(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier([]string{server.URL}, go_stockx_client.NewNoopLogger(), WithRetries(3, time.Millisecond, 5*time.Millisecond))
+
+	if err := notifier.Notify(testEvent()); err == nil {
+		t.Fatalf("expected an error")
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("expected 1 attempt, got %d", got)
+	}
+}
+
+func TestWebhookWritesDeadLetter(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "dead-letters.jsonl")
+
+	notifier := NewWebhookNotifier([]string{server.URL}, go_stockx_client.NewNoopLogger(),
+		WithRetries(2, time.Millisecond, 5*time.Millisecond),
+		WithDeadLetterFile(path),
+	)
+
+	if err := notifier.Notify(testEvent()); err == nil {
+		t.Fatalf("expected an error")
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Fatalf("expected 3 attempts, got %d", got)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open dead letter file: %v", err)
+	}
+	defer file.Close()
+
+	var entries []deadLetter
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry deadLetter
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("failed to decode dead letter: %v", err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 dead letter, got %d", len(entries))
+	}
+
+	if entries[0].URL != server.URL || entries[0].Attempts != 3 || entries[0].Error == "" {
+		t.Fatalf("unexpected dead letter: %+v", entries[0])
+	}
+
+	var event Event
+	if err := json.Unmarshal(entries[0].Payload, &event); err != nil || event.Message != "price dropped" {
+		t.Fatalf("expected the original payload in the dead letter, got %s", entries[0].Payload)
+	}
+}
+
+func TestWebhookNegativeRetries(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier([]string{server.URL}, go_stockx_client.NewNoopLogger(),
+		WithRetries(-1, time.Millisecond, time.Millisecond),
+		WithDeadLetterFile(filepath.Join(t.TempDir(), "dead-letters.jsonl")),
+	)
+
+	if err := notifier.Notify(testEvent()); err == nil {
+		t.Fatalf("expected an error")
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("expected 1 attempt, got %d", got)
+	}
+}
+
+func TestWebhookBackoffIsCancelable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "dead-letters.jsonl")
+
+	notifier := NewWebhookNotifier([]string{server.URL}, go_stockx_client.NewNoopLogger(),
+		WithRetries(3, time.Millisecond, time.Minute),
+		WithDeadLetterFile(path),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := notifier.NotifyContext(ctx, testEvent())
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("expected the backoff to be canceled, took %s", elapsed)
+	}
+
+	if _, err = os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected canceled deliveries not to be written to the dead letter file, got %v", err)
+	}
+}
+
+func TestWebhookBackoffIsClamped(t *testing.T) {
+	notifier := NewWebhookNotifier(nil, go_stockx_client.NewNoopLogger(), WithRetries(3, time.Second, 5*time.Second)).(*webhookNotifier)
+
+	backoff := time.Second
+	for i := 0; i < 100; i++ {
+		backoff = notifier.nextBackoff(backoff)
+	}
+
+	if backoff != 5*time.Second {
+		t.Fatalf("expected the backoff to stay at the maximum, got %s", backoff)
+	}
+
+	unlimited := NewWebhookNotifier(nil, go_stockx_client.NewNoopLogger(), WithRetries(3, time.Second, 0)).(*webhookNotifier)
+
+	backoff = time.Second
+	for i := 0; i < 100; i++ {
+		backoff = unlimited.nextBackoff(backoff)
+	}
+
+	if backoff <= 0 {
+		t.Fatalf("expected the backoff not to overflow, got %s", backoff)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if got := parseRetryAfter("3"); got != 3*time.Second {
+		t.Fatalf("expected 3s, got %s", got)
+	}
+
+	if got := parseRetryAfter(""); got != 0 {
+		t.Fatalf("expected 0, got %s", got)
+	}
+
+	if got := parseRetryAfter(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)); got < 59*time.Minute {
+		t.Fatalf("expected roughly an hour, got %s", got)
+	}
+}
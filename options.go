@@ -0,0 +1,128 @@
+package go_stockx_client
+
+import (
+	"context"
+	"strings"
+
+	http "github.com/bogdanfinn/fhttp"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type ClientOption func(config *clientConfig)
+
+type clientConfig struct {
+	schemaMode       SchemaMode
+	baseUrl          string
+	challengeHandler ChallengeHandler
+	challengeRetries int
+	identities       []BrowserIdentity
+	randomIdentity   bool
+	language         string
+	headerBuilders   []HeaderBuilder
+	metrics          Metrics
+	tracerProvider   trace.TracerProvider
+	transport        Transport
+	cassettePath     string
+	cassetteMode     CassetteMode
+	cassetteOptions  []CassetteOption
+}
+
+// WithSchemaMode enables schema drift detection for all decoded responses, see SchemaMode.
+func WithSchemaMode(schemaMode SchemaMode) ClientOption {
+	return func(config *clientConfig) {
+		config.schemaMode = schemaMode
+	}
+}
+
+// WithTransport replaces the default tls-client transport.
+func WithTransport(transport Transport) ClientOption {
+	return func(config *clientConfig) {
+		config.transport = transport
+	}
+}
+
+// WithCassette records all requests to the cassette file at path or replays them from it without
+// network access, see CassetteMode.
+func WithCassette(mode CassetteMode, path string, options ...CassetteOption) ClientOption {
+	return func(config *clientConfig) {
+		config.cassetteMode = mode
+		config.cassettePath = path
+		config.cassetteOptions = options
+	}
+}
+
+// WithBaseURL sends all requests to baseUrl instead of https://stockx.com/, for example to a stockxtest.Server.
+func WithBaseURL(baseUrl string) ClientOption {
+	return func(config *clientConfig) {
+		config.baseUrl = strings.TrimSuffix(baseUrl, "/") + "/"
+	}
+}
+
+// WithChallengeHandler calls handler whenever a request is answered with a bot protection challenge and sends
+// the request again, up to maxRetries times, if the handler asks for it. maxRetries of zero or less uses 2.
+func WithChallengeHandler(handler ChallengeHandler, maxRetries int) ClientOption {
+	return func(config *clientConfig) {
+		config.challengeHandler = handler
+		config.challengeRetries = maxRetries
+		if maxRetries <= 0 {
+			config.challengeRetries = defaultChallengeRetries
+		}
+	}
+}
+
+// WithIdentity uses the given browser identity instead of DefaultIdentity.
+func WithIdentity(identity BrowserIdentity) ClientOption {
+	return func(config *clientConfig) {
+		config.identities = []BrowserIdentity{identity}
+		config.randomIdentity = false
+	}
+}
+
+// WithIdentities starts with a random identity of the pool, RotateIdentity switches to the next one.
+// DefaultIdentities is a good pool to start with. The identities are copied, so changing the passed slice
+// afterwards does not bypass their validation in NewClient.
+func WithIdentities(identities ...BrowserIdentity) ClientOption {
+	identities = append([]BrowserIdentity(nil), identities...)
+
+	return func(config *clientConfig) {
+		config.identities = identities
+		config.randomIdentity = true
+	}
+}
+
+// WithLanguage overrides the language (e.g. "en-GB") of the accept-language and referer headers, which is
+// derived from the locale of the client by default, see LanguageForCountry.
+func WithLanguage(language string) ClientOption {
+	return func(config *clientConfig) {
+		config.language = language
+	}
+}
+
+// WithHeaderBuilder adds a header builder called for every request, builders are called in the order they were added.
+func WithHeaderBuilder(builder HeaderBuilder) ClientOption {
+	return func(config *clientConfig) {
+		config.headerBuilders = append(config.headerBuilders, builder)
+	}
+}
+
+// WithHeader sends the header with every request, replacing the header of the browser identity if it exists.
+func WithHeader(key string, value string) ClientOption {
+	return WithHeaderBuilder(HeaderBuilderFunc(func(_ context.Context, _ string, header http.Header) {
+		header[strings.ToLower(key)] = []string{value}
+	}))
+}
+
+// WithMetrics reports requests, method calls, retries, decode and proxy failures to metrics.
+func WithMetrics(metrics Metrics) ClientOption {
+	return func(config *clientConfig) {
+		config.metrics = metrics
+	}
+}
+
+// WithTracerProvider emits opentelemetry spans for SearchProducts, GetProduct and every http request.
+// Use WithContext on the client to make them children of your own spans.
+func WithTracerProvider(tracerProvider trace.TracerProvider) ClientOption {
+	return func(config *clientConfig) {
+		config.tracerProvider = tracerProvider
+	}
+}
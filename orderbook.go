@@ -0,0 +1,99 @@
+package go_stockx_client
+
+import "fmt"
+
+type OrderSide string
+
+const (
+	OrderSideAsk OrderSide = "ask"
+	OrderSideBid OrderSide = "bid"
+)
+
+type PriceLevel struct {
+	Price       float64 `json:"price"`
+	Quantity    int     `json:"quantity"`
+	Size        string  `json:"size"`
+	VariantUUID string  `json:"variantUuid"`
+}
+
+// OrderBook holds the ask ladder sorted from the lowest price up and the bid ladder sorted from the highest price down.
+// A product wide order book contains the levels of all sizes, use ForSize to narrow it down.
+type OrderBook struct {
+	Asks []PriceLevel `json:"asks"`
+	Bids []PriceLevel `json:"bids"`
+}
+
+func (o *OrderBook) ForSize(size string) *OrderBook {
+	filtered := &OrderBook{}
+
+	for _, level := range o.Asks {
+		if level.Size == size {
+			filtered.Asks = append(filtered.Asks, level)
+		}
+	}
+
+	for _, level := range o.Bids {
+		if level.Size == size {
+			filtered.Bids = append(filtered.Bids, level)
+		}
+	}
+
+	return filtered
+}
+
+// DepthAtPrice returns how many asks are available at or below the price, or how many bids are placed at or above the price.
+func (o *OrderBook) DepthAtPrice(side OrderSide, price float64) int {
+	depth := 0
+
+	switch side {
+	case OrderSideAsk:
+		for _, level := range o.Asks {
+			if level.Price <= price {
+				depth += level.Quantity
+			}
+		}
+	case OrderSideBid:
+		for _, level := range o.Bids {
+			if level.Price >= price {
+				depth += level.Quantity
+			}
+		}
+	}
+
+	return depth
+}
+
+// CostToBuy returns the total price of buying quantity items from the cheapest asks.
+func (o *OrderBook) CostToBuy(quantity int) (float64, error) {
+	return walkLevels(o.Asks, quantity)
+}
+
+// ProceedsToSell returns the total price received by selling quantity items into the highest bids.
+func (o *OrderBook) ProceedsToSell(quantity int) (float64, error) {
+	return walkLevels(o.Bids, quantity)
+}
+
+func walkLevels(levels []PriceLevel, quantity int) (float64, error) {
+	total := 0.0
+	remaining := quantity
+
+	for _, level := range levels {
+		if remaining <= 0 {
+			break
+		}
+
+		take := level.Quantity
+		if take > remaining {
+			take = remaining
+		}
+
+		total += float64(take) * level.Price
+		remaining -= take
+	}
+
+	if remaining > 0 {
+		return 0, fmt.Errorf("%w: missing %d of %d", ErrInsufficientLiquidity, remaining, quantity)
+	}
+
+	return total, nil
+}
@@ -0,0 +1,117 @@
+package go_stockx_client
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func testOrderBook() *OrderBook {
+	asks := []ProductActivityItemResponse{
+		{Amount: 130, Localamount: 120, Shoesize: "10", Skuuuid: "size-10"},
+		{Amount: 125, Localamount: 115, Shoesize: "10", Skuuuid: "size-10", Frequency: 2},
+		{Amount: 130, Localamount: 120, Shoesize: "10", Skuuuid: "size-10"},
+		{Amount: 140, Shoesize: "9", Skuuuid: "size-9"},
+	}
+
+	bids := []ProductActivityItemResponse{
+		{Amount: 100, Localamount: 95, Shoesize: "10", Skuuuid: "size-10"},
+		{Amount: 110, Localamount: 105, Shoesize: "10", Skuuuid: "size-10", Frequency: 3},
+		{Amount: 90, Shoesize: "9", Skuuuid: "size-9"},
+	}
+
+	return parseOrderBook(asks, bids)
+}
+
+func TestParseOrderBook(t *testing.T) {
+	orderBook := testOrderBook()
+
+	expected := &OrderBook{
+		Asks: []PriceLevel{
+			{Price: 115, Quantity: 2, Size: "10", VariantUUID: "size-10"},
+			{Price: 120, Quantity: 2, Size: "10", VariantUUID: "size-10"},
+			{Price: 140, Quantity: 1, Size: "9", VariantUUID: "size-9"},
+		},
+		Bids: []PriceLevel{
+			{Price: 105, Quantity: 3, Size: "10", VariantUUID: "size-10"},
+			{Price: 95, Quantity: 1, Size: "10", VariantUUID: "size-10"},
+			{Price: 90, Quantity: 1, Size: "9", VariantUUID: "size-9"},
+		},
+	}
+
+	if !reflect.DeepEqual(orderBook, expected) {
+		t.Fatalf("unexpected order book:\n%+v\nexpected:\n%+v", orderBook, expected)
+	}
+
+	forSize := orderBook.ForSize("9")
+	if len(forSize.Asks) != 1 || forSize.Asks[0].Price != 140 || len(forSize.Bids) != 1 || forSize.Bids[0].Price != 90 {
+		t.Fatalf("unexpected order book for size 9: %+v", forSize)
+	}
+
+	if empty := orderBook.ForSize("12"); len(empty.Asks) != 0 || len(empty.Bids) != 0 {
+		t.Fatalf("expected an empty order book for an unknown size, got %+v", empty)
+	}
+}
+
+func TestOrderBookDepthAtPrice(t *testing.T) {
+	orderBook := testOrderBook()
+
+	tests := []struct {
+		side  OrderSide
+		price float64
+		depth int
+	}{
+		{side: OrderSideAsk, price: 110, depth: 0},
+		{side: OrderSideAsk, price: 115, depth: 2},
+		{side: OrderSideAsk, price: 130, depth: 4},
+		{side: OrderSideAsk, price: 200, depth: 5},
+		{side: OrderSideBid, price: 110, depth: 0},
+		{side: OrderSideBid, price: 95, depth: 4},
+		{side: OrderSideBid, price: 0, depth: 5},
+	}
+
+	for _, test := range tests {
+		if depth := orderBook.DepthAtPrice(test.side, test.price); depth != test.depth {
+			t.Errorf("expected a %s depth of %d at %.0f, got %d", test.side, test.depth, test.price, depth)
+		}
+	}
+}
+
+func TestOrderBookWalksLevels(t *testing.T) {
+	orderBook := testOrderBook()
+
+	tests := []struct {
+		name     string
+		walk     func(quantity int) (float64, error)
+		quantity int
+		total    float64
+	}{
+		{name: "buy within the first level", walk: orderBook.CostToBuy, quantity: 1, total: 115},
+		{name: "buy across levels", walk: orderBook.CostToBuy, quantity: 3, total: 2*115 + 120},
+		{name: "buy the whole ladder", walk: orderBook.CostToBuy, quantity: 5, total: 2*115 + 2*120 + 140},
+		{name: "sell within the first level", walk: orderBook.ProceedsToSell, quantity: 3, total: 3 * 105},
+		{name: "sell across levels", walk: orderBook.ProceedsToSell, quantity: 5, total: 3*105 + 95 + 90},
+		{name: "nothing", walk: orderBook.CostToBuy, quantity: 0, total: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			total, err := test.walk(test.quantity)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if total != test.total {
+				t.Fatalf("expected %.0f, got %.0f", test.total, total)
+			}
+		})
+	}
+
+	if _, err := orderBook.CostToBuy(6); !errors.Is(err, ErrInsufficientLiquidity) {
+		t.Fatalf("expected ErrInsufficientLiquidity, got %v", err)
+	}
+
+	if _, err := orderBook.ForSize("9").ProceedsToSell(2); !errors.Is(err, ErrInsufficientLiquidity) {
+		t.Fatalf("expected ErrInsufficientLiquidity, got %v", err)
+	}
+}
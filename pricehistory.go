@@ -0,0 +1,90 @@
+package go_stockx_client
+
+import (
+	"fmt"
+	"time"
+)
+
+type ResamplePeriod string
+
+const (
+	ResampleDaily  ResamplePeriod = "daily"
+	ResampleWeekly ResamplePeriod = "weekly"
+)
+
+// PricePoint is a single chart value, Currency is the currency of the client which requested it.
+type PricePoint struct {
+	Time     time.Time `json:"time"`
+	Price    float64   `json:"price"`
+	Currency string    `json:"currency"`
+}
+
+type OHLC struct {
+	Time     time.Time `json:"time"`
+	Open     float64   `json:"open"`
+	High     float64   `json:"high"`
+	Low      float64   `json:"low"`
+	Close    float64   `json:"close"`
+	Points   int       `json:"points"`
+	Currency string    `json:"currency"`
+}
+
+// ResampleOHLC groups price points into daily buckets or weekly buckets starting on monday (both in UTC).
+// The points have to be sorted by time, as returned by GetPriceHistory. Unknown periods and points in
+// different currencies return an error.
+func ResampleOHLC(points []PricePoint, period ResamplePeriod) ([]OHLC, error) {
+	if period != ResampleDaily && period != ResampleWeekly {
+		return nil, fmt.Errorf("unsupported resample period: %q", period)
+	}
+
+	var candles []OHLC
+
+	for _, point := range points {
+		if point.Currency != points[0].Currency {
+			return nil, fmt.Errorf("can not resample price points in %q and %q", points[0].Currency, point.Currency)
+		}
+
+		bucket := bucketStart(point.Time, period)
+
+		if len(candles) > 0 && candles[len(candles)-1].Time.Equal(bucket) {
+			candle := &candles[len(candles)-1]
+			candle.Close = point.Price
+			candle.Points++
+
+			if point.Price > candle.High {
+				candle.High = point.Price
+			}
+
+			if point.Price < candle.Low {
+				candle.Low = point.Price
+			}
+
+			continue
+		}
+
+		candles = append(candles, OHLC{
+			Time:     bucket,
+			Open:     point.Price,
+			High:     point.Price,
+			Low:      point.Price,
+			Close:    point.Price,
+			Points:   1,
+			Currency: point.Currency,
+		})
+	}
+
+	return candles, nil
+}
+
+func bucketStart(t time.Time, period ResamplePeriod) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	if period == ResampleDaily {
+		return day
+	}
+
+	offset := (int(day.Weekday()) + 6) % 7
+
+	return day.AddDate(0, 0, -offset)
+}
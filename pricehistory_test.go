@@ -0,0 +1,81 @@
+package go_stockx_client
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestResampleOHLC(t *testing.T) {
+	// 2023-10-01 is a sunday, the next two points fall into the following week
+	sunday := time.Date(2023, 10, 1, 10, 0, 0, 0, time.UTC)
+
+	points := []PricePoint{
+		{Time: sunday, Price: 100, Currency: "EUR"},
+		{Time: sunday.Add(time.Hour), Price: 120, Currency: "EUR"},
+		{Time: sunday.Add(24 * time.Hour), Price: 90, Currency: "EUR"},
+		{Time: sunday.Add(48 * time.Hour), Price: 110, Currency: "EUR"},
+	}
+
+	daily, err := ResampleOHLC(points, ResampleDaily)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(daily) != 3 {
+		t.Fatalf("expected 3 daily candles, got %d", len(daily))
+	}
+
+	first := daily[0]
+	if first.Open != 100 || first.High != 120 || first.Low != 100 || first.Close != 120 || first.Points != 2 || first.Currency != "EUR" {
+		t.Fatalf("unexpected candle: %+v", first)
+	}
+
+	weekly, err := ResampleOHLC(points, ResampleWeekly)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(weekly) != 2 {
+		t.Fatalf("expected 2 weekly candles, got %d", len(weekly))
+	}
+
+	if !weekly[1].Time.Equal(time.Date(2023, 10, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected weeks to start on monday, got %s", weekly[1].Time)
+	}
+
+	if weekly[1].Open != 90 || weekly[1].Close != 110 || weekly[1].Low != 90 || weekly[1].High != 110 {
+		t.Fatalf("unexpected candle: %+v", weekly[1])
+	}
+}
+
+func TestResampleOHLCErrors(t *testing.T) {
+	points := []PricePoint{{Time: time.Now(), Price: 100, Currency: "EUR"}}
+
+	if _, err := ResampleOHLC(points, "monthly"); err == nil {
+		t.Fatalf("expected an error for an unknown period")
+	}
+
+	points = append(points, PricePoint{Time: time.Now(), Price: 100, Currency: "USD"})
+	if _, err := ResampleOHLC(points, ResampleDaily); err == nil {
+		t.Fatalf("expected an error for mixed currencies")
+	}
+}
+
+func TestParsePriceHistoryCurrency(t *testing.T) {
+	response := ProductChartResponse{}
+	err := json.Unmarshal([]byte(`{"series":[{"name":"Price","data":[[1696154400000,150],[1696118400000,140]]}]}`), &response)
+	if err != nil {
+		t.Fatalf("failed to decode chart response: %v", err)
+	}
+
+	points := parsePriceHistory(response, "EUR")
+
+	if len(points) != 2 {
+		t.Fatalf("expected 2 points, got %d", len(points))
+	}
+
+	if points[0].Price != 140 || points[0].Currency != "EUR" || !points[0].Time.Equal(time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected point: %+v", points[0])
+	}
+}
@@ -0,0 +1,134 @@
+package go_stockx_client
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+type ProductRefKind string
+
+const (
+	ProductRefKindURLKey ProductRefKind = "urlKey"
+	ProductRefKindUUID   ProductRefKind = "uuid"
+	ProductRefKindID     ProductRefKind = "id"
+)
+
+var (
+	uuidPattern     = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	numericPattern  = regexp.MustCompile(`^[0-9]+$`)
+	urlKeyPattern   = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+	localePattern   = regexp.MustCompile(`^[a-z]{2}(?:-[a-z]{2})?$`)
+	ignoredSegments = map[string]bool{"buy": true, "sell": true, "api": true, "products": true}
+)
+
+// ProductRef identifies a product. A uuid can either be the uuid of a product or of one of its variants,
+// GetProduct resolves variant uuids to the parent product. Size is the size preselected in a pasted url.
+type ProductRef struct {
+	Kind       ProductRefKind `json:"kind"`
+	Identifier string         `json:"identifier"`
+	Size       string         `json:"size,omitempty"`
+	Locale     string         `json:"locale,omitempty"`
+}
+
+// ParseProductRef accepts stockx urls in any locale (https://stockx.com/de-de/nike-dunk-low-retro-white-black?size=10),
+// url keys, product and variant uuids and numeric ids. Identifiers without a slash which are neither of these are
+// passed through unchanged as url keys, the way GetProduct always sent them to stockx.
+func ParseProductRef(input string) (ProductRef, error) {
+	value := strings.TrimSpace(input)
+	if value == "" {
+		return ProductRef{}, fmt.Errorf("%w: empty product identifier", ErrInvalidProductRef)
+	}
+
+	if !strings.Contains(value, "/") && !strings.Contains(value, "?") {
+		ref, _ := parseProductIdentifier(value)
+		return ref, nil
+	}
+
+	if !strings.Contains(value, "://") {
+		value = "https://" + strings.TrimPrefix(value, "//")
+	}
+
+	parsedUrl, err := url.Parse(value)
+	if err != nil {
+		return ProductRef{}, fmt.Errorf("%w: %q is not a valid url: %s", ErrInvalidProductRef, input, err.Error())
+	}
+
+	host := strings.ToLower(parsedUrl.Hostname())
+	if host != "stockx.com" && !strings.HasSuffix(host, ".stockx.com") {
+		return ProductRef{}, fmt.Errorf("%w: %q is not a stockx url", ErrInvalidProductRef, input)
+	}
+
+	ref := ProductRef{
+		Size: parsedUrl.Query().Get("size"),
+	}
+
+	var segments []string
+	for _, segment := range strings.Split(parsedUrl.Path, "/") {
+		if segment != "" && !ignoredSegments[strings.ToLower(segment)] {
+			segments = append(segments, segment)
+		}
+	}
+
+	// the first segment is only a locale if the product follows it, https://stockx.com/ps-vr is a product
+	if len(segments) > 1 && localePattern.MatchString(strings.ToLower(segments[0])) {
+		ref.Locale = strings.ToLower(segments[0])
+		segments = segments[1:]
+	}
+
+	if len(segments) == 0 {
+		return ProductRef{}, fmt.Errorf("%w: %q does not contain a product", ErrInvalidProductRef, input)
+	}
+
+	parsedRef, ok := parseProductIdentifier(segments[len(segments)-1])
+	if !ok {
+		return ProductRef{}, fmt.Errorf("%w: %q", ErrInvalidProductRef, input)
+	}
+
+	ref.Kind = parsedRef.Kind
+	ref.Identifier = parsedRef.Identifier
+
+	return ref, nil
+}
+
+// parseProductIdentifier returns false for identifiers which do not look like a uuid, id or url key,
+// they are returned unchanged as url key.
+func parseProductIdentifier(identifier string) (ProductRef, bool) {
+	switch {
+	case uuidPattern.MatchString(identifier):
+		return ProductRef{Kind: ProductRefKindUUID, Identifier: strings.ToLower(identifier)}, true
+	case numericPattern.MatchString(identifier):
+		return ProductRef{Kind: ProductRefKindID, Identifier: identifier}, true
+	case urlKeyPattern.MatchString(strings.ToLower(identifier)):
+		return ProductRef{Kind: ProductRefKindURLKey, Identifier: strings.ToLower(identifier)}, true
+	default:
+		return ProductRef{Kind: ProductRefKindURLKey, Identifier: identifier}, false
+	}
+}
+
+func (r ProductRef) String() string {
+	return r.Identifier
+}
+
+// Variant returns the variant of the product details the reference points to, either by the preselected
+// size or because the reference is a variant uuid. It returns nil if nothing was preselected.
+func (r ProductRef) Variant(productDetails *ProductDetails) *ProductDetailsVariant {
+	for i, variant := range productDetails.Variants {
+		if r.Kind == ProductRefKindUUID && variant.UUID == r.Identifier {
+			return &productDetails.Variants[i]
+		}
+	}
+
+	if r.Size == "" {
+		return nil
+	}
+
+	for i, variant := range productDetails.Variants {
+		if strings.EqualFold(variant.Size, r.Size) {
+			return &productDetails.Variants[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package go_stockx_client
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseProductRef(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected ProductRef
+	}{
+		{
+			name:     "url key",
+			input:    "nike-dunk-low-retro-white-black-2021",
+			expected: ProductRef{Kind: ProductRefKindURLKey, Identifier: "nike-dunk-low-retro-white-black-2021"},
+		},
+		{
+			name:     "url key with surrounding spaces and upper case",
+			input:    "  Nike-Dunk-Low-Retro-White-Black-2021 ",
+			expected: ProductRef{Kind: ProductRefKindURLKey, Identifier: "nike-dunk-low-retro-white-black-2021"},
+		},
+		{
+			name:     "url key with underscores is passed through",
+			input:    "Air_Jordan_1",
+			expected: ProductRef{Kind: ProductRefKindURLKey, Identifier: "Air_Jordan_1"},
+		},
+		{
+			name:     "uuid",
+			input:    "5E6A1E57-1C7D-435A-82BD-5666A13560FE",
+			expected: ProductRef{Kind: ProductRefKindUUID, Identifier: "5e6a1e57-1c7d-435a-82bd-5666a13560fe"},
+		},
+		{
+			name:     "numeric id",
+			input:    "1234567",
+			expected: ProductRef{Kind: ProductRefKindID, Identifier: "1234567"},
+		},
+		{
+			name:     "url",
+			input:    "https://stockx.com/nike-dunk-low-retro-white-black-2021",
+			expected: ProductRef{Kind: ProductRefKindURLKey, Identifier: "nike-dunk-low-retro-white-black-2021"},
+		},
+		{
+			name:     "url with locale and size",
+			input:    "https://stockx.com/de-de/nike-dunk-low-retro-white-black-2021?size=10",
+			expected: ProductRef{Kind: ProductRefKindURLKey, Identifier: "nike-dunk-low-retro-white-black-2021", Size: "10", Locale: "de-de"},
+		},
+		{
+			name:     "url with short locale",
+			input:    "https://stockx.com/fr/nike-dunk-low-retro-white-black-2021",
+			expected: ProductRef{Kind: ProductRefKindURLKey, Identifier: "nike-dunk-low-retro-white-black-2021", Locale: "fr"},
+		},
+		{
+			name:     "url key which looks like a locale",
+			input:    "https://stockx.com/ps-vr",
+			expected: ProductRef{Kind: ProductRefKindURLKey, Identifier: "ps-vr"},
+		},
+		{
+			name:     "url key which looks like a locale after a locale",
+			input:    "https://stockx.com/en-gb/ps-vr",
+			expected: ProductRef{Kind: ProductRefKindURLKey, Identifier: "ps-vr", Locale: "en-gb"},
+		},
+		{
+			name:     "buy url",
+			input:    "https://stockx.com/buy/nike-dunk-low-retro-white-black-2021?size=9.5",
+			expected: ProductRef{Kind: ProductRefKindURLKey, Identifier: "nike-dunk-low-retro-white-black-2021", Size: "9.5"},
+		},
+		{
+			name:     "api url with variant uuid",
+			input:    "https://stockx.com/api/products/2B1FB7D8-6B9C-4B4D-8D9F-6C8B2C1E7A02?includes=market",
+			expected: ProductRef{Kind: ProductRefKindUUID, Identifier: "2b1fb7d8-6b9c-4b4d-8d9f-6c8b2c1e7a02"},
+		},
+		{
+			name:     "url without scheme",
+			input:    "stockx.com/nike-dunk-low-retro-white-black-2021",
+			expected: ProductRef{Kind: ProductRefKindURLKey, Identifier: "nike-dunk-low-retro-white-black-2021"},
+		},
+		{
+			name:     "subdomain with trailing slash",
+			input:    "https://www.stockx.com/nike-dunk-low-retro-white-black-2021/",
+			expected: ProductRef{Kind: ProductRefKindURLKey, Identifier: "nike-dunk-low-retro-white-black-2021"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ref, err := ParseProductRef(test.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if ref != test.expected {
+				t.Fatalf("expected %+v, got %+v", test.expected, ref)
+			}
+		})
+	}
+}
+
+func TestParseProductRefErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "empty", input: "  "},
+		{name: "foreign host", input: "https://goat.com/sneakers/nike-dunk-low-retro-white-black-2021"},
+		{name: "host lookalike", input: "https://notstockx.com/nike-dunk-low-retro-white-black-2021"},
+		{name: "homepage", input: "https://stockx.com/"},
+		{name: "api without product", input: "https://stockx.com/api/products/"},
+		{name: "invalid url", input: "https://stockx.com/%zz"},
+		{name: "invalid url key", input: "https://stockx.com/nike_dunk low"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ref, err := ParseProductRef(test.input)
+			if !errors.Is(err, ErrInvalidProductRef) {
+				t.Fatalf("expected ErrInvalidProductRef, got %+v, %v", ref, err)
+			}
+		})
+	}
+}
+
+func TestProductRefVariant(t *testing.T) {
+	productDetails := &ProductDetails{
+		Variants: []ProductDetailsVariant{
+			{UUID: "1a0ea6c7-5a8b-4a3c-9c8e-5b7a1b0d6f01", Size: "9"},
+			{UUID: "2b1fb7d8-6b9c-4b4d-8d9f-6c8b2c1e7a02", Size: "10W"},
+		},
+	}
+
+	tests := []struct {
+		input string
+		size  string
+	}{
+		{input: "https://stockx.com/nike-dunk-low?size=10w", size: "10W"},
+		{input: "2b1fb7d8-6b9c-4b4d-8d9f-6c8b2c1e7a02", size: "10W"},
+		{input: "https://stockx.com/nike-dunk-low?size=12", size: ""},
+		{input: "nike-dunk-low", size: ""},
+	}
+
+	for _, test := range tests {
+		ref, err := ParseProductRef(test.input)
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %v", test.input, err)
+		}
+
+		variant := ref.Variant(productDetails)
+		if (variant == nil && test.size != "") || (variant != nil && variant.Size != test.size) {
+			t.Errorf("expected size %q for %s, got %+v", test.size, test.input, variant)
+		}
+	}
+}
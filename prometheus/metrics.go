@@ -0,0 +1,135 @@
+package prometheus
+
+import (
+	"strconv"
+	"time"
+
+	go_stockx_client "github.com/bogdanfinn/go-stockx-client"
+	prom "github.com/prometheus/client_golang/prometheus"
+)
+
+var _ go_stockx_client.Metrics = (*Metrics)(nil)
+
+const namespace = "stockx_client"
+
+// Metrics implements go_stockx_client.Metrics with prometheus collectors.
+type Metrics struct {
+	requests          *prom.CounterVec
+	requestDuration   *prom.HistogramVec
+	operations        *prom.CounterVec
+	operationDuration *prom.HistogramVec
+	retries           *prom.CounterVec
+	decodeFailures    *prom.CounterVec
+	proxyFailures     *prom.CounterVec
+	cacheHits         *prom.CounterVec
+}
+
+// NewMetrics creates the collectors and registers them with registerer, use prom.DefaultRegisterer for the
+// global registry. constLabels are added to every metric, for example to tell several clients apart.
+func NewMetrics(registerer prom.Registerer, constLabels prom.Labels) (*Metrics, error) {
+	m := &Metrics{
+		requests: prom.NewCounterVec(prom.CounterOpts{
+			Namespace:   namespace,
+			Name:        "requests_total",
+			Help:        "Number of http requests sent to stockx by endpoint and status code, status code 0 means no response was received.",
+			ConstLabels: constLabels,
+		}, []string{"endpoint", "status"}),
+		requestDuration: prom.NewHistogramVec(prom.HistogramOpts{
+			Namespace:   namespace,
+			Name:        "request_duration_seconds",
+			Help:        "Latency of http requests sent to stockx by endpoint.",
+			ConstLabels: constLabels,
+			Buckets:     prom.DefBuckets,
+		}, []string{"endpoint"}),
+		operations: prom.NewCounterVec(prom.CounterOpts{
+			Namespace:   namespace,
+			Name:        "operations_total",
+			Help:        "Number of client method calls by operation and result.",
+			ConstLabels: constLabels,
+		}, []string{"operation", "result"}),
+		operationDuration: prom.NewHistogramVec(prom.HistogramOpts{
+			Namespace:   namespace,
+			Name:        "operation_duration_seconds",
+			Help:        "Latency of client method calls by operation, including all requests they send.",
+			ConstLabels: constLabels,
+			Buckets:     prom.DefBuckets,
+		}, []string{"operation"}),
+		retries: prom.NewCounterVec(prom.CounterOpts{
+			Namespace:   namespace,
+			Name:        "retries_total",
+			Help:        "Number of requests sent again after a bot protection challenge by endpoint.",
+			ConstLabels: constLabels,
+		}, []string{"endpoint"}),
+		decodeFailures: prom.NewCounterVec(prom.CounterOpts{
+			Namespace:   namespace,
+			Name:        "decode_failures_total",
+			Help:        "Number of responses which could not be decoded or failed the strict schema check by endpoint.",
+			ConstLabels: constLabels,
+		}, []string{"endpoint"}),
+		proxyFailures: prom.NewCounterVec(prom.CounterOpts{
+			Namespace:   namespace,
+			Name:        "proxy_failures_total",
+			Help:        "Number of requests through a proxy which failed without response or with status code 407 by endpoint.",
+			ConstLabels: constLabels,
+		}, []string{"endpoint"}),
+		cacheHits: prom.NewCounterVec(prom.CounterOpts{
+			Namespace:   namespace,
+			Name:        "cache_hits_total",
+			Help:        "Number of responses served from a replayed cassette instead of stockx by endpoint.",
+			ConstLabels: constLabels,
+		}, []string{"endpoint"}),
+	}
+
+	for _, collector := range m.collectors() {
+		err := registerer.Register(collector)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+func (m *Metrics) collectors() []prom.Collector {
+	return []prom.Collector{
+		m.requests,
+		m.requestDuration,
+		m.operations,
+		m.operationDuration,
+		m.retries,
+		m.decodeFailures,
+		m.proxyFailures,
+		m.cacheHits,
+	}
+}
+
+func (m *Metrics) ObserveRequest(endpoint string, statusCode int, duration time.Duration) {
+	m.requests.WithLabelValues(endpoint, strconv.Itoa(statusCode)).Inc()
+	m.requestDuration.WithLabelValues(endpoint).Observe(duration.Seconds())
+}
+
+func (m *Metrics) ObserveOperation(operation string, duration time.Duration, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+
+	m.operations.WithLabelValues(operation, result).Inc()
+	m.operationDuration.WithLabelValues(operation).Observe(duration.Seconds())
+}
+
+func (m *Metrics) IncRetries(endpoint string) {
+	m.retries.WithLabelValues(endpoint).Inc()
+}
+
+func (m *Metrics) IncDecodeFailures(endpoint string) {
+	m.decodeFailures.WithLabelValues(endpoint).Inc()
+}
+
+func (m *Metrics) IncProxyFailures(endpoint string) {
+	m.proxyFailures.WithLabelValues(endpoint).Inc()
+}
+
+func (m *Metrics) IncCacheHits(endpoint string) {
+	m.cacheHits.WithLabelValues(endpoint).Inc()
+}
@@ -0,0 +1,128 @@
+package prometheus_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	go_stockx_client "github.com/bogdanfinn/go-stockx-client"
+	stockxprometheus "github.com/bogdanfinn/go-stockx-client/prometheus"
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+const shippedCassette = "../testdata/cassettes/dunk-low.json"
+
+func TestMetricsCollectors(t *testing.T) {
+	registry := prom.NewRegistry()
+
+	metrics, err := stockxprometheus.NewMetrics(registry, prom.Labels{"client": "eu"})
+	if err != nil {
+		t.Fatalf("failed to create metrics: %v", err)
+	}
+
+	metrics.ObserveRequest("search", 200, 120*time.Millisecond)
+	metrics.ObserveRequest("search", 0, time.Second)
+	metrics.ObserveOperation("SearchProducts", time.Second, errors.New("failed"))
+	metrics.IncRetries("product details")
+	metrics.IncDecodeFailures("product details")
+	metrics.IncProxyFailures("search")
+	metrics.IncCacheHits("search")
+
+	expected := `
+# HELP stockx_client_requests_total Number of http requests sent to stockx by endpoint and status code, status code 0 means no response was received.
+# TYPE stockx_client_requests_total counter
+stockx_client_requests_total{client="eu",endpoint="search",status="0"} 1
+stockx_client_requests_total{client="eu",endpoint="search",status="200"} 1
+# HELP stockx_client_operations_total Number of client method calls by operation and result.
+# TYPE stockx_client_operations_total counter
+stockx_client_operations_total{client="eu",operation="SearchProducts",result="error"} 1
+# HELP stockx_client_retries_total Number of requests sent again after a bot protection challenge by endpoint.
+# TYPE stockx_client_retries_total counter
+stockx_client_retries_total{client="eu",endpoint="product details"} 1
+# HELP stockx_client_decode_failures_total Number of responses which could not be decoded or failed the strict schema check by endpoint.
+# TYPE stockx_client_decode_failures_total counter
+stockx_client_decode_failures_total{client="eu",endpoint="product details"} 1
+# HELP stockx_client_proxy_failures_total Number of requests through a proxy which failed without response or with status code 407 by endpoint.
+# TYPE stockx_client_proxy_failures_total counter
+stockx_client_proxy_failures_total{client="eu",endpoint="search"} 1
+# HELP stockx_client_cache_hits_total Number of responses served from a replayed cassette instead of stockx by endpoint.
+# TYPE stockx_client_cache_hits_total counter
+stockx_client_cache_hits_total{client="eu",endpoint="search"} 1
+`
+
+	err = testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"stockx_client_requests_total",
+		"stockx_client_operations_total",
+		"stockx_client_retries_total",
+		"stockx_client_decode_failures_total",
+		"stockx_client_proxy_failures_total",
+		"stockx_client_cache_hits_total",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := testutil.GatherAndCount(registry, "stockx_client_request_duration_seconds", "stockx_client_operation_duration_seconds")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 2 {
+		t.Fatalf("expected 2 latency histograms, got %d", count)
+	}
+
+	problems, err := testutil.GatherAndLint(registry)
+	if err != nil || len(problems) != 0 {
+		t.Fatalf("unexpected lint problems: %+v, %v", problems, err)
+	}
+
+	if _, err = stockxprometheus.NewMetrics(registry, prom.Labels{"client": "eu"}); err == nil {
+		t.Fatalf("expected registering the collectors twice to fail")
+	}
+}
+
+func TestMetricsFromReplayedClient(t *testing.T) {
+	registry := prom.NewRegistry()
+
+	metrics, err := stockxprometheus.NewMetrics(registry, nil)
+	if err != nil {
+		t.Fatalf("failed to create metrics: %v", err)
+	}
+
+	client, err := go_stockx_client.NewClient("EUR", "DE", go_stockx_client.NewNoopLogger(), false,
+		go_stockx_client.WithCassette(go_stockx_client.CassetteModeReplay, shippedCassette),
+		go_stockx_client.WithMetrics(metrics),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if _, err = client.GetProduct("nike-dunk-low-retro-white-black-2021"); err != nil {
+		t.Fatalf("failed to load product: %v", err)
+	}
+
+	expected := `
+# HELP stockx_client_cache_hits_total Number of responses served from a replayed cassette instead of stockx by endpoint.
+# TYPE stockx_client_cache_hits_total counter
+stockx_client_cache_hits_total{endpoint="homepage"} 1
+stockx_client_cache_hits_total{endpoint="product details"} 1
+# HELP stockx_client_operations_total Number of client method calls by operation and result.
+# TYPE stockx_client_operations_total counter
+stockx_client_operations_total{operation="GetProduct",result="success"} 1
+# HELP stockx_client_requests_total Number of http requests sent to stockx by endpoint and status code, status code 0 means no response was received.
+# TYPE stockx_client_requests_total counter
+stockx_client_requests_total{endpoint="homepage",status="200"} 1
+stockx_client_requests_total{endpoint="product details",status="200"} 1
+`
+
+	err = testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"stockx_client_cache_hits_total",
+		"stockx_client_operations_total",
+		"stockx_client_requests_total",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,256 +1,217 @@
 package go_stockx_client
 
-import "time"
-
 type SearchResultProductResponse struct {
-	Brand    string `json:"brand"`
-	Colorway string `json:"colorway"`
+	Brand    FlexString `json:"brand"`
+	Colorway FlexString `json:"colorway"`
 	Media    struct {
-		Thumburl string `json:"thumbUrl"`
+		Thumburl FlexString `json:"thumbUrl"`
 	} `json:"media"`
-	Productcategory  string `json:"productCategory"`
-	Shortdescription string `json:"shortDescription"`
-	Title            string `json:"title"`
-	Urlkey           string `json:"urlKey"`
-	Objectid         string `json:"objectID"`
+	Productcategory  FlexString `json:"productCategory"`
+	Shortdescription FlexString `json:"shortDescription"`
+	Title            FlexString `json:"title"`
+	Urlkey           FlexString `json:"urlKey"`
+	Objectid         FlexString `json:"objectID"`
 }
 
 type ProductSearchResultResponse struct {
 	Pagination struct {
-		Query        string      `json:"query"`
-		Queryid      string      `json:"queryID"`
-		Index        string      `json:"index"`
-		Limit        string      `json:"limit"`
-		Page         int         `json:"page"`
-		Total        int         `json:"total"`
-		Lastpage     string      `json:"lastPage"`
-		Sort         []string    `json:"sort"`
-		Order        []string    `json:"order"`
-		Currentpage  string      `json:"currentPage"`
-		Nextpage     interface{} `json:"nextPage"`
-		Previouspage interface{} `json:"previousPage"`
+		Query        FlexString   `json:"query"`
+		Queryid      FlexString   `json:"queryID,omitempty"`
+		Index        FlexString   `json:"index,omitempty"`
+		Limit        FlexString   `json:"limit"`
+		Page         FlexInt      `json:"page"`
+		Total        FlexInt      `json:"total"`
+		Lastpage     FlexString   `json:"lastPage"`
+		Sort         []FlexString `json:"sort"`
+		Order        []FlexString `json:"order"`
+		Currentpage  FlexString   `json:"currentPage"`
+		Nextpage     FlexString   `json:"nextPage"`
+		Previouspage FlexString   `json:"previousPage"`
 	} `json:"Pagination"`
 	Facets struct {
 		Browseverticals struct {
-			Sneakers int `json:"sneakers"`
+			Sneakers FlexInt `json:"sneakers"`
 		} `json:"browseVerticals"`
 	} `json:"Facets"`
 	Products []SearchResultProductResponse `json:"Products"`
 }
 
+type RelatedProductsResponse struct {
+	Products []SearchResultProductResponse `json:"Products"`
+}
+
+type ProductActivityResponse struct {
+	Pagination struct {
+		Limit        FlexInt    `json:"limit"`
+		Page         FlexInt    `json:"page"`
+		Total        FlexInt    `json:"total"`
+		Lastpage     FlexString `json:"lastPage"`
+		Nextpage     FlexString `json:"nextPage"`
+		Previouspage FlexString `json:"previousPage"`
+	} `json:"Pagination"`
+	ProductActivity []ProductActivityItemResponse `json:"ProductActivity"`
+}
+
+type ProductActivityItemResponse struct {
+	Chainid       FlexString   `json:"chainId"`
+	Amount        FlexFloat    `json:"amount"`
+	Createdat     NullableTime `json:"createdAt"`
+	Shoesize      FlexString   `json:"shoeSize"`
+	Productid     FlexString   `json:"productId"`
+	Skuuuid       FlexString   `json:"skuUuid"`
+	Localamount   FlexFloat    `json:"localAmount"`
+	Localcurrency FlexString   `json:"localCurrency"`
+	Frequency     FlexInt      `json:"frequency"`
+}
+
+type ProductChartResponse struct {
+	Series []struct {
+		Name FlexString    `json:"name"`
+		Data [][]FlexFloat `json:"data"`
+	} `json:"series"`
+}
+
 type ProductResponse struct {
 	Product Product `json:"Product"`
 }
 
-type Product struct {
-	ID                   string        `json:"id"`
-	UUID                 string        `json:"uuid"`
-	Brand                string        `json:"brand"`
-	Colorway             string        `json:"colorway"`
-	Condition            string        `json:"condition"`
-	Countryofmanufacture string        `json:"countryOfManufacture"`
-	Gender               string        `json:"gender"`
-	Contentgroup         string        `json:"contentGroup"`
-	Minimumbid           int           `json:"minimumBid"`
-	Name                 string        `json:"name"`
-	Primarycategory      string        `json:"primaryCategory"`
-	Secondarycategory    string        `json:"secondaryCategory"`
-	Ushtscode            string        `json:"usHtsCode"`
-	Ushtsdescription     string        `json:"usHtsDescription"`
-	Productcategory      string        `json:"productCategory"`
-	Releasedate          string        `json:"releaseDate"`
-	Retailprice          int           `json:"retailPrice"`
-	Shoe                 string        `json:"shoe"`
-	Shortdescription     string        `json:"shortDescription"`
-	Styleid              string        `json:"styleId"`
-	Tickersymbol         string        `json:"tickerSymbol"`
-	Title                string        `json:"title"`
-	Datatype             string        `json:"dataType"`
-	Urlkey               string        `json:"urlKey"`
-	Sizelocale           string        `json:"sizeLocale"`
-	Sizetitle            string        `json:"sizeTitle"`
-	Sizedescriptor       string        `json:"sizeDescriptor"`
-	Sizealldescriptor    string        `json:"sizeAllDescriptor"`
-	Description          string        `json:"description"`
-	Lithiumionbattery    bool          `json:"lithiumIonBattery"`
-	Hazardousmaterial    interface{}   `json:"hazardousMaterial"`
-	Type                 bool          `json:"type"`
-	Alim                 int           `json:"aLim"`
-	Year                 int           `json:"year"`
-	Shippinggroup        string        `json:"shippingGroup"`
+// ProductCore holds the fields shared by products and their children (variants).
+type ProductCore struct {
+	ID                   FlexString    `json:"id"`
+	UUID                 FlexString    `json:"uuid"`
+	Brand                FlexString    `json:"brand"`
+	Colorway             FlexString    `json:"colorway"`
+	Condition            FlexString    `json:"condition"`
+	Countryofmanufacture FlexString    `json:"countryOfManufacture"`
+	Gender               FlexString    `json:"gender"`
+	Contentgroup         FlexString    `json:"contentGroup"`
+	Minimumbid           FlexInt       `json:"minimumBid"`
+	Name                 FlexString    `json:"name"`
+	Primarycategory      FlexString    `json:"primaryCategory"`
+	Secondarycategory    FlexString    `json:"secondaryCategory"`
+	Ushtscode            FlexString    `json:"usHtsCode"`
+	Ushtsdescription     FlexString    `json:"usHtsDescription"`
+	Productcategory      FlexString    `json:"productCategory"`
+	Releasedate          FlexString    `json:"releaseDate"`
+	Retailprice          FlexInt       `json:"retailPrice"`
+	Shoe                 FlexString    `json:"shoe"`
+	Shortdescription     FlexString    `json:"shortDescription"`
+	Styleid              FlexString    `json:"styleId"`
+	Tickersymbol         FlexString    `json:"tickerSymbol"`
+	Title                FlexString    `json:"title"`
+	Datatype             FlexString    `json:"dataType"`
+	Urlkey               FlexString    `json:"urlKey"`
+	Sizelocale           FlexString    `json:"sizeLocale"`
+	Sizetitle            FlexString    `json:"sizeTitle"`
+	Sizedescriptor       FlexString    `json:"sizeDescriptor"`
+	Sizealldescriptor    FlexString    `json:"sizeAllDescriptor"`
+	Description          FlexString    `json:"description"`
+	Lithiumionbattery    FlexBool      `json:"lithiumIonBattery"`
+	Hazardousmaterial    FlexBool      `json:"hazardousMaterial"`
+	Type                 FlexBool      `json:"type"`
+	Alim                 FlexInt       `json:"aLim"`
+	Year                 FlexInt       `json:"year"`
+	Shippinggroup        FlexString    `json:"shippingGroup"`
 	Portfolioitems       []interface{} `json:"PortfolioItems"`
-	Shipping             struct {
-		Totaldaystoship         int  `json:"totalDaysToShip"`
-		Hasadditionaldaystoship bool `json:"hasAdditionalDaysToShip"`
-		Deliverydayslowerbound  int  `json:"deliveryDaysLowerBound"`
-		Deliverydaysupperbound  int  `json:"deliveryDaysUpperBound"`
-	} `json:"shipping"`
-	Enhancedimage struct {
-		Productuuid string `json:"productUuid"`
-		Imagekey    string `json:"imageKey"`
-		Imagecount  int    `json:"imageCount"`
-	} `json:"enhancedImage"`
-	Media struct {
-		Num360        []string      `json:"360"`
-		Imageurl      string        `json:"imageUrl"`
-		Smallimageurl string        `json:"smallImageUrl"`
-		Thumburl      string        `json:"thumbUrl"`
-		Has360        bool          `json:"has360"`
-		Gallery       []interface{} `json:"gallery"`
-	} `json:"media"`
-	Charitycondition int `json:"charityCondition"`
-	Breadcrumbs      []struct {
-		Level int    `json:"level"`
-		Name  string `json:"name"`
-		URL   string `json:"url"`
-	} `json:"breadcrumbs"`
-	Market struct {
-		Productid                 int         `json:"productId"`
-		Skuuuid                   interface{} `json:"skuUuid"`
-		Productuuid               string      `json:"productUuid"`
-		Lowestask                 int         `json:"lowestAsk"`
-		Lowestasksize             interface{} `json:"lowestAskSize"`
-		Parentlowestask           int         `json:"parentLowestAsk"`
-		Numberofasks              int         `json:"numberOfAsks"`
-		Hasasks                   int         `json:"hasAsks"`
-		Salesthisperiod           int         `json:"salesThisPeriod"`
-		Saleslastperiod           int         `json:"salesLastPeriod"`
-		Highestbid                int         `json:"highestBid"`
-		Highestbidsize            interface{} `json:"highestBidSize"`
-		Numberofbids              int         `json:"numberOfBids"`
-		Hasbids                   int         `json:"hasBids"`
-		Annualhigh                int         `json:"annualHigh"`
-		Annuallow                 int         `json:"annualLow"`
-		Deadstockrangelow         int         `json:"deadstockRangeLow"`
-		Deadstockrangehigh        int         `json:"deadstockRangeHigh"`
-		Volatility                float64     `json:"volatility"`
-		Deadstocksold             int         `json:"deadstockSold"`
-		Pricepremium              float64     `json:"pricePremium"`
-		Averagedeadstockprice     int         `json:"averageDeadstockPrice"`
-		Lastsale                  int         `json:"lastSale"`
-		Lastsalesize              string      `json:"lastSaleSize"`
-		Saleslast72Hours          int         `json:"salesLast72Hours"`
-		Changevalue               int         `json:"changeValue"`
-		Changepercentage          float64     `json:"changePercentage"`
-		Abschangepercentage       float64     `json:"absChangePercentage"`
-		Totaldollars              int         `json:"totalDollars"`
-		Updatedat                 int         `json:"updatedAt"`
-		Lastlowestasktime         int         `json:"lastLowestAskTime"`
-		Lasthighestbidtime        int         `json:"lastHighestBidTime"`
-		Lastsaledate              time.Time   `json:"lastSaleDate"`
-		Createdat                 time.Time   `json:"createdAt"`
-		Deadstocksoldrank         int         `json:"deadstockSoldRank"`
-		Pricepremiumrank          int         `json:"pricePremiumRank"`
-		Averagedeadstockpricerank int         `json:"averageDeadstockPriceRank"`
-		Featured                  interface{} `json:"featured"`
-		Lowestaskfloat            float64     `json:"lowestAskFloat"`
-		Highestbidfloat           float64     `json:"highestBidFloat"`
-	} `json:"market"`
-	Children map[string]ProductWithoutChildren `json:"children"`
+	Charitycondition     FlexInt       `json:"charityCondition"`
+	Shipping             Shipping      `json:"shipping"`
+	Enhancedimage        EnhancedImage `json:"enhancedImage"`
+	Media                Media         `json:"media"`
+	Breadcrumbs          []Breadcrumb  `json:"breadcrumbs"`
+	Market               MarketData    `json:"market"`
 }
 
+// Product is the product details response. Parentuuid, Shoesize and Gtins are only sent when a variant
+// uuid is requested, the response is the variant then.
+type Product struct {
+	ProductCore
+	Parentuuid FlexString                        `json:"parentUuid,omitempty"`
+	Shoesize   FlexString                        `json:"shoeSize,omitempty"`
+	Gtins      []Gtin                            `json:"gtins,omitempty"`
+	Children   map[string]ProductWithoutChildren `json:"children"`
+}
+
+// ProductWithoutChildren is a child (variant) of a product.
 type ProductWithoutChildren struct {
-	ID                   string        `json:"id"`
-	UUID                 string        `json:"uuid"`
-	Brand                string        `json:"brand"`
-	Colorway             string        `json:"colorway"`
-	Condition            string        `json:"condition"`
-	Countryofmanufacture string        `json:"countryOfManufacture"`
-	Gender               string        `json:"gender"`
-	Contentgroup         string        `json:"contentGroup"`
-	Minimumbid           int           `json:"minimumBid"`
-	Name                 string        `json:"name"`
-	Primarycategory      string        `json:"primaryCategory"`
-	Secondarycategory    string        `json:"secondaryCategory"`
-	Ushtscode            string        `json:"usHtsCode"`
-	Ushtsdescription     string        `json:"usHtsDescription"`
-	Productcategory      string        `json:"productCategory"`
-	Releasedate          string        `json:"releaseDate"`
-	Retailprice          int           `json:"retailPrice"`
-	Shoe                 string        `json:"shoe"`
-	Shortdescription     string        `json:"shortDescription"`
-	Styleid              string        `json:"styleId"`
-	Tickersymbol         string        `json:"tickerSymbol"`
-	Title                string        `json:"title"`
-	Datatype             string        `json:"dataType"`
-	Urlkey               string        `json:"urlKey"`
-	Sizelocale           string        `json:"sizeLocale"`
-	Sizetitle            string        `json:"sizeTitle"`
-	Sizedescriptor       string        `json:"sizeDescriptor"`
-	Sizealldescriptor    string        `json:"sizeAllDescriptor"`
-	Description          string        `json:"description"`
-	Lithiumionbattery    bool          `json:"lithiumIonBattery"`
-	Hazardousmaterial    interface{}   `json:"hazardousMaterial"`
-	Type                 bool          `json:"type"`
-	Alim                 int           `json:"aLim"`
-	Year                 int           `json:"year"`
-	Shippinggroup        string        `json:"shippingGroup"`
-	Portfolioitems       []interface{} `json:"PortfolioItems"`
-	Shipping             struct {
-		Totaldaystoship         int  `json:"totalDaysToShip"`
-		Hasadditionaldaystoship bool `json:"hasAdditionalDaysToShip"`
-		Deliverydayslowerbound  int  `json:"deliveryDaysLowerBound"`
-		Deliverydaysupperbound  int  `json:"deliveryDaysUpperBound"`
-	} `json:"shipping"`
-	Enhancedimage struct {
-		Productuuid string `json:"productUuid"`
-		Imagekey    string `json:"imageKey"`
-		Imagecount  int    `json:"imageCount"`
-	} `json:"enhancedImage"`
-	Media struct {
-		Num360        []string      `json:"360"`
-		Imageurl      string        `json:"imageUrl"`
-		Smallimageurl string        `json:"smallImageUrl"`
-		Thumburl      string        `json:"thumbUrl"`
-		Has360        bool          `json:"has360"`
-		Gallery       []interface{} `json:"gallery"`
-	} `json:"media"`
-	Charitycondition int `json:"charityCondition"`
-	Breadcrumbs      []struct {
-		Level int    `json:"level"`
-		Name  string `json:"name"`
-		URL   string `json:"url"`
-	} `json:"breadcrumbs"`
-	Market struct {
-		Productid                 int         `json:"productId"`
-		Skuuuid                   interface{} `json:"skuUuid"`
-		Productuuid               string      `json:"productUuid"`
-		Lowestask                 int         `json:"lowestAsk"`
-		Lowestasksize             interface{} `json:"lowestAskSize"`
-		Parentlowestask           int         `json:"parentLowestAsk"`
-		Numberofasks              int         `json:"numberOfAsks"`
-		Hasasks                   int         `json:"hasAsks"`
-		Salesthisperiod           int         `json:"salesThisPeriod"`
-		Saleslastperiod           int         `json:"salesLastPeriod"`
-		Highestbid                int         `json:"highestBid"`
-		Highestbidsize            interface{} `json:"highestBidSize"`
-		Numberofbids              int         `json:"numberOfBids"`
-		Hasbids                   int         `json:"hasBids"`
-		Annualhigh                int         `json:"annualHigh"`
-		Annuallow                 int         `json:"annualLow"`
-		Deadstockrangelow         int         `json:"deadstockRangeLow"`
-		Deadstockrangehigh        int         `json:"deadstockRangeHigh"`
-		Volatility                float64     `json:"volatility"`
-		Deadstocksold             int         `json:"deadstockSold"`
-		Pricepremium              float64     `json:"pricePremium"`
-		Averagedeadstockprice     int         `json:"averageDeadstockPrice"`
-		Lastsale                  int         `json:"lastSale"`
-		Lastsalesize              string      `json:"lastSaleSize"`
-		Saleslast72Hours          int         `json:"salesLast72Hours"`
-		Changevalue               int         `json:"changeValue"`
-		Changepercentage          float64     `json:"changePercentage"`
-		Abschangepercentage       float64     `json:"absChangePercentage"`
-		Totaldollars              int         `json:"totalDollars"`
-		Updatedat                 int         `json:"updatedAt"`
-		Lastlowestasktime         int         `json:"lastLowestAskTime"`
-		Lasthighestbidtime        int         `json:"lastHighestBidTime"`
-		Lastsaledate              time.Time   `json:"lastSaleDate"`
-		Createdat                 time.Time   `json:"createdAt"`
-		Deadstocksoldrank         int         `json:"deadstockSoldRank"`
-		Pricepremiumrank          int         `json:"pricePremiumRank"`
-		Averagedeadstockpricerank int         `json:"averageDeadstockPriceRank"`
-		Featured                  interface{} `json:"featured"`
-		Lowestaskfloat            float64     `json:"lowestAskFloat"`
-		Highestbidfloat           float64     `json:"highestBidFloat"`
-	} `json:"market"`
+	ProductCore
+	Parentuuid FlexString `json:"parentUuid"`
+	Shoesize   FlexString `json:"shoeSize"`
+	Gtins      []Gtin     `json:"gtins"`
+}
+
+type Shipping struct {
+	Totaldaystoship         FlexInt  `json:"totalDaysToShip"`
+	Hasadditionaldaystoship FlexBool `json:"hasAdditionalDaysToShip"`
+	Deliverydayslowerbound  FlexInt  `json:"deliveryDaysLowerBound"`
+	Deliverydaysupperbound  FlexInt  `json:"deliveryDaysUpperBound"`
+}
+
+type EnhancedImage struct {
+	Productuuid FlexString `json:"productUuid"`
+	Imagekey    FlexString `json:"imageKey"`
+	Imagecount  FlexInt    `json:"imageCount"`
+}
+
+type Media struct {
+	Num360        []FlexString  `json:"360"`
+	Imageurl      FlexString    `json:"imageUrl"`
+	Smallimageurl FlexString    `json:"smallImageUrl"`
+	Thumburl      FlexString    `json:"thumbUrl"`
+	Has360        FlexBool      `json:"has360"`
+	Gallery       []interface{} `json:"gallery"`
+}
+
+type Breadcrumb struct {
+	Level FlexInt    `json:"level"`
+	Name  FlexString `json:"name"`
+	URL   FlexString `json:"url"`
+}
+
+type Gtin struct {
+	Type       FlexString `json:"type"`
+	Identifier FlexString `json:"identifier"`
+}
+
+// MarketData is the market summary of a product or of a single variant.
+type MarketData struct {
+	Productid                 FlexInt      `json:"productId"`
+	Skuuuid                   FlexString   `json:"skuUuid"`
+	Productuuid               FlexString   `json:"productUuid"`
+	Lowestask                 FlexInt      `json:"lowestAsk"`
+	Lowestasksize             FlexString   `json:"lowestAskSize"`
+	Parentlowestask           FlexInt      `json:"parentLowestAsk"`
+	Numberofasks              FlexInt      `json:"numberOfAsks"`
+	Hasasks                   FlexInt      `json:"hasAsks"`
+	Salesthisperiod           FlexInt      `json:"salesThisPeriod"`
+	Saleslastperiod           FlexInt      `json:"salesLastPeriod"`
+	Highestbid                FlexInt      `json:"highestBid"`
+	Highestbidsize            FlexString   `json:"highestBidSize"`
+	Numberofbids              FlexInt      `json:"numberOfBids"`
+	Hasbids                   FlexInt      `json:"hasBids"`
+	Annualhigh                FlexInt      `json:"annualHigh"`
+	Annuallow                 FlexInt      `json:"annualLow"`
+	Deadstockrangelow         FlexInt      `json:"deadstockRangeLow"`
+	Deadstockrangehigh        FlexInt      `json:"deadstockRangeHigh"`
+	Volatility                FlexFloat    `json:"volatility"`
+	Deadstocksold             FlexInt      `json:"deadstockSold"`
+	Pricepremium              FlexFloat    `json:"pricePremium"`
+	Averagedeadstockprice     FlexInt      `json:"averageDeadstockPrice"`
+	Lastsale                  FlexInt      `json:"lastSale"`
+	Lastsalesize              FlexString   `json:"lastSaleSize"`
+	Saleslast72Hours          FlexInt      `json:"salesLast72Hours"`
+	Changevalue               FlexInt      `json:"changeValue"`
+	Changepercentage          FlexFloat    `json:"changePercentage"`
+	Abschangepercentage       FlexFloat    `json:"absChangePercentage"`
+	Totaldollars              FlexInt      `json:"totalDollars"`
+	Updatedat                 FlexInt      `json:"updatedAt"`
+	Lastlowestasktime         FlexInt      `json:"lastLowestAskTime"`
+	Lasthighestbidtime        FlexInt      `json:"lastHighestBidTime"`
+	Lastsaledate              NullableTime `json:"lastSaleDate"`
+	Createdat                 NullableTime `json:"createdAt"`
+	Deadstocksoldrank         FlexInt      `json:"deadstockSoldRank"`
+	Pricepremiumrank          FlexInt      `json:"pricePremiumRank"`
+	Averagedeadstockpricerank FlexInt      `json:"averageDeadstockPriceRank"`
+	Featured                  FlexBool     `json:"featured"`
+	Lowestaskfloat            FlexFloat    `json:"lowestAskFloat"`
+	Highestbidfloat           FlexFloat    `json:"highestBidFloat"`
 }
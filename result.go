@@ -0,0 +1,43 @@
+package go_stockx_client
+
+import (
+	"time"
+
+	http "github.com/bogdanfinn/fhttp"
+)
+
+// Result wraps a parsed value together with the response it was parsed from. Raw holds the unmodified
+// response body, which makes it possible to debug schema changes or to archive the source data.
+// Status, Header and Raw are set even if the request returned an error, as long as a response was received.
+type Result[T any] struct {
+	Value     T           `json:"value"`
+	Raw       []byte      `json:"raw"`
+	Status    int         `json:"status"`
+	Header    http.Header `json:"header"`
+	FetchedAt time.Time   `json:"fetchedAt"`
+
+	// SchemaReport is only set if the client was created with a schema mode other than SchemaModeOff.
+	SchemaReport *SchemaReport `json:"schemaReport,omitempty"`
+}
+
+type rawResponse struct {
+	statusCode   int
+	body         []byte
+	header       http.Header
+	fetchedAt    time.Time
+	schemaReport *SchemaReport
+}
+
+func newResult[T any](raw *rawResponse) *Result[T] {
+	if raw == nil {
+		return nil
+	}
+
+	return &Result[T]{
+		Raw:          raw.body,
+		Status:       raw.statusCode,
+		Header:       raw.header,
+		FetchedAt:    raw.fetchedAt,
+		SchemaReport: raw.schemaReport,
+	}
+}
@@ -0,0 +1,332 @@
+package go_stockx_client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+type SchemaMode int
+
+const (
+	// SchemaModeOff decodes responses like encoding/json does: unknown fields are ignored and missing fields stay zero.
+	SchemaModeOff SchemaMode = iota
+	// SchemaModeDiagnostic compares every response against the response structs, logs differences as warnings
+	// and attaches a SchemaReport to returned results.
+	SchemaModeDiagnostic
+	// SchemaModeStrict works like SchemaModeDiagnostic but fails the request with a *SchemaDriftError.
+	SchemaModeStrict
+)
+
+type SchemaIssueKind string
+
+const (
+	SchemaIssueUnknownField SchemaIssueKind = "unknown_field"
+	SchemaIssueMissingField SchemaIssueKind = "missing_field"
+	SchemaIssueTypeMismatch SchemaIssueKind = "type_mismatch"
+)
+
+// SchemaIssue describes one difference between a response and the struct it is decoded into.
+// Path uses the json field names, map entries are written as "*" and slice elements as "[]".
+type SchemaIssue struct {
+	Kind     SchemaIssueKind `json:"kind"`
+	Path     string          `json:"path"`
+	Expected string          `json:"expected,omitempty"`
+	Actual   string          `json:"actual,omitempty"`
+}
+
+func (i SchemaIssue) String() string {
+	switch i.Kind {
+	case SchemaIssueUnknownField:
+		return fmt.Sprintf("unknown field %s (%s)", i.Path, i.Actual)
+	case SchemaIssueMissingField:
+		return fmt.Sprintf("missing field %s (%s)", i.Path, i.Expected)
+	default:
+		return fmt.Sprintf("type mismatch at %s: expected %s, got %s", i.Path, i.Expected, i.Actual)
+	}
+}
+
+type SchemaReport struct {
+	Endpoint string        `json:"endpoint"`
+	Issues   []SchemaIssue `json:"issues"`
+}
+
+func (r *SchemaReport) HasIssues() bool {
+	return r != nil && len(r.Issues) > 0
+}
+
+func (r *SchemaReport) IssuesOfKind(kind SchemaIssueKind) []SchemaIssue {
+	var issues []SchemaIssue
+
+	for _, issue := range r.Issues {
+		if issue.Kind == kind {
+			issues = append(issues, issue)
+		}
+	}
+
+	return issues
+}
+
+type SchemaDriftError struct {
+	Report *SchemaReport
+}
+
+func (e *SchemaDriftError) Error() string {
+	var issues []string
+	for _, issue := range e.Report.Issues {
+		issues = append(issues, issue.String())
+	}
+
+	return fmt.Sprintf("response of %s does not match the expected schema: %s", e.Report.Endpoint, strings.Join(issues, "; "))
+}
+
+// CheckSchema compares the json document with the type of target (a pointer to the struct the document
+// is decoded into) and reports unknown fields, missing fields and type mismatches. Field names are
+// matched case insensitive, like encoding/json does. Fields tagged with omitempty are optional and never
+// reported as missing.
+func CheckSchema(data []byte, target interface{}) (*SchemaReport, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var document interface{}
+	err := decoder.Decode(&document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode json document: %w", err)
+	}
+
+	checker := &schemaChecker{seen: map[string]bool{}}
+	checker.check("$", document, reflect.TypeOf(target))
+
+	sort.SliceStable(checker.issues, func(i, j int) bool {
+		return checker.issues[i].Path < checker.issues[j].Path
+	})
+
+	return &SchemaReport{Issues: checker.issues}, nil
+}
+
+var (
+	timeType        = reflect.TypeOf(time.Time{})
+	unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	flexibleType    = reflect.TypeOf((*flexible)(nil)).Elem()
+)
+
+type schemaChecker struct {
+	issues []SchemaIssue
+	seen   map[string]bool
+}
+
+func (c *schemaChecker) add(issue SchemaIssue) {
+	key := string(issue.Kind) + "|" + issue.Path
+	if c.seen[key] {
+		return
+	}
+
+	c.seen[key] = true
+	c.issues = append(c.issues, issue)
+}
+
+func (c *schemaChecker) check(path string, value interface{}, t reflect.Type) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if value == nil || t.Kind() == reflect.Interface {
+		return
+	}
+
+	if t == timeType {
+		if _, ok := value.(string); !ok {
+			c.mismatch(path, "time string", value)
+		}
+
+		return
+	}
+
+	if reflect.PtrTo(t).Implements(flexibleType) {
+		if !reflect.New(t).Interface().(flexible).acceptsJsonKind(jsonKind(value)) {
+			c.mismatch(path, t.Name(), value)
+		}
+
+		return
+	}
+
+	// types with their own decoding rules decide themselves what they accept
+	if reflect.PtrTo(t).Implements(unmarshalerType) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			c.mismatch(path, "object", value)
+			return
+		}
+
+		c.checkObject(path, object, t)
+	case reflect.Map:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			c.mismatch(path, "object", value)
+			return
+		}
+
+		for _, entry := range object {
+			c.check(path+".*", entry, t.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		list, ok := value.([]interface{})
+		if !ok {
+			c.mismatch(path, "array", value)
+			return
+		}
+
+		for _, entry := range list {
+			c.check(path+"[]", entry, t.Elem())
+		}
+	case reflect.String:
+		if _, ok := value.(string); !ok {
+			c.mismatch(path, "string", value)
+		}
+	case reflect.Bool:
+		if _, ok := value.(bool); !ok {
+			c.mismatch(path, "bool", value)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		number, ok := value.(json.Number)
+		if !ok {
+			c.mismatch(path, "integer", value)
+			return
+		}
+
+		if _, err := number.Int64(); err != nil {
+			c.add(SchemaIssue{Kind: SchemaIssueTypeMismatch, Path: path, Expected: "integer", Actual: "float"})
+		}
+	case reflect.Float32, reflect.Float64:
+		if _, ok := value.(json.Number); !ok {
+			c.mismatch(path, "number", value)
+		}
+	}
+}
+
+func (c *schemaChecker) checkObject(path string, object map[string]interface{}, t reflect.Type) {
+	fields := map[string]reflect.StructField{}
+	collectJsonFields(t, fields)
+
+	present := map[string]bool{}
+
+	for key, value := range object {
+		field, ok := fields[strings.ToLower(key)]
+		if !ok {
+			c.add(SchemaIssue{Kind: SchemaIssueUnknownField, Path: path + "." + key, Actual: jsonKind(value)})
+			continue
+		}
+
+		present[strings.ToLower(key)] = true
+		c.check(path+"."+key, value, field.Type)
+	}
+
+	for key, field := range fields {
+		if present[key] || isOptionalField(field) {
+			continue
+		}
+
+		c.add(SchemaIssue{Kind: SchemaIssueMissingField, Path: path + "." + jsonFieldName(field), Expected: field.Type.String()})
+	}
+}
+
+func (c *schemaChecker) mismatch(path string, expected string, value interface{}) {
+	c.add(SchemaIssue{Kind: SchemaIssueTypeMismatch, Path: path, Expected: expected, Actual: jsonKind(value)})
+}
+
+// collectJsonFields maps the lower cased json names of all fields of t to the field, including the
+// fields promoted from embedded structs. Like in encoding/json, fields of the outer struct win.
+func collectJsonFields(t reflect.Type, fields map[string]reflect.StructField) {
+	var embeddedTypes []reflect.Type
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if field.Anonymous && field.Tag.Get("json") == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+
+			if embedded.Kind() == reflect.Struct {
+				embeddedTypes = append(embeddedTypes, embedded)
+				continue
+			}
+		}
+
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := jsonFieldName(field)
+		if name == "-" {
+			continue
+		}
+
+		fields[strings.ToLower(name)] = field
+	}
+
+	for _, embedded := range embeddedTypes {
+		promoted := map[string]reflect.StructField{}
+		collectJsonFields(embedded, promoted)
+
+		for name, field := range promoted {
+			if _, exists := fields[name]; !exists {
+				fields[name] = field
+			}
+		}
+	}
+}
+
+// isOptionalField reports whether field is tagged with omitempty, which marks fields stockx only sends sometimes.
+func isOptionalField(field reflect.StructField) bool {
+	for _, option := range strings.Split(field.Tag.Get("json"), ",")[1:] {
+		if option == "omitempty" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func jsonFieldName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "" {
+		return field.Name
+	}
+
+	return name
+}
+
+func jsonKind(value interface{}) string {
+	switch typed := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "bool"
+	case json.Number:
+		if _, err := typed.Int64(); err == nil {
+			return "integer"
+		}
+
+		return "float"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
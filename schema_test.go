@@ -0,0 +1,94 @@
+package go_stockx_client_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	go_stockx_client "github.com/bogdanfinn/go-stockx-client"
+	"github.com/bogdanfinn/go-stockx-client/stockxtest"
+)
+
+func TestCheckSchemaOptionalFields(t *testing.T) {
+	target := &struct {
+		Required go_stockx_client.FlexString `json:"required"`
+		Optional go_stockx_client.FlexString `json:"optional,omitempty"`
+	}{}
+
+	report, err := go_stockx_client.CheckSchema([]byte(`{"extra": 1}`), target)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	missing := report.IssuesOfKind(go_stockx_client.SchemaIssueMissingField)
+	if len(missing) != 1 || missing[0].Path != "$.required" {
+		t.Fatalf("expected only the required field to be missing, got %v", missing)
+	}
+
+	unknown := report.IssuesOfKind(go_stockx_client.SchemaIssueUnknownField)
+	if len(unknown) != 1 || unknown[0].Path != "$.extra" {
+		t.Fatalf("expected the extra field to be unknown, got %v", unknown)
+	}
+}
+
+func TestShippedFixturesMatchSchema(t *testing.T) {
+	fixtures := []struct {
+		name   string
+		body   []byte
+		target interface{}
+	}{
+		{name: "product", body: stockxtest.DefaultProductFixture(), target: &go_stockx_client.ProductResponse{}},
+		{name: "search", body: stockxtest.DefaultSearchFixture(), target: &go_stockx_client.ProductSearchResultResponse{}},
+	}
+
+	for _, fixture := range fixtures {
+		report, err := go_stockx_client.CheckSchema(fixture.body, fixture.target)
+		if err != nil {
+			t.Fatalf("failed to check %s fixture: %v", fixture.name, err)
+		}
+
+		if report.HasIssues() {
+			t.Errorf("%s fixture does not match the schema: %v", fixture.name, report.Issues)
+		}
+	}
+}
+
+func TestStrictSchemaModeAgainstFakeServer(t *testing.T) {
+	server := stockxtest.NewServer()
+	defer server.Close()
+
+	client := newTestClient(t, server, go_stockx_client.WithSchemaMode(go_stockx_client.SchemaModeStrict))
+
+	if _, err := client.SearchProducts("dunk low", 10); err != nil {
+		t.Fatalf("search failed in strict mode: %v", err)
+	}
+
+	if _, err := client.GetProduct(stockxtest.DefaultProductURLKey); err != nil {
+		t.Fatalf("product details failed in strict mode: %v", err)
+	}
+}
+
+func TestCheckSchemaChildOnlyFields(t *testing.T) {
+	document := map[string]map[string]interface{}{}
+	if err := json.Unmarshal(stockxtest.DefaultProductFixture(), &document); err != nil {
+		t.Fatalf("failed to decode fixture: %v", err)
+	}
+
+	for _, child := range document["Product"]["children"].(map[string]interface{}) {
+		delete(child.(map[string]interface{}), "gtins")
+	}
+
+	body, err := json.Marshal(document)
+	if err != nil {
+		t.Fatalf("failed to encode fixture: %v", err)
+	}
+
+	report, err := go_stockx_client.CheckSchema(body, &go_stockx_client.ProductResponse{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	missing := report.IssuesOfKind(go_stockx_client.SchemaIssueMissingField)
+	if len(missing) != 1 || missing[0].Path != "$.Product.children.*.gtins" {
+		t.Fatalf("expected only the gtins of the children to be missing, got %v", missing)
+	}
+}
@@ -0,0 +1,29 @@
+package go_stockx_client_test
+
+import (
+	"testing"
+
+	http "github.com/bogdanfinn/fhttp"
+	"github.com/bogdanfinn/go-stockx-client/stockxtest"
+)
+
+func TestSearchProductsReturnsStatusErrors(t *testing.T) {
+	server := stockxtest.NewServer()
+	defer server.Close()
+
+	client := newTestClient(t, server)
+
+	server.Fail(stockxtest.RateLimited().On("/api/browse"))
+
+	results, err := client.SearchProducts("dunk low", 10)
+	if err == nil {
+		t.Fatalf("expected the rate limited search to fail, got %+v", results)
+	}
+
+	server.SetResponse("/api/browse", http.StatusServiceUnavailable, []byte(`{"error": "Service Unavailable"}`))
+
+	results, err = client.SearchProducts("dunk low", 10)
+	if err == nil {
+		t.Fatalf("expected the unavailable search to fail, got %+v", results)
+	}
+}
@@ -0,0 +1,172 @@
+package stockxtest
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"strings"
+	"time"
+
+	go_stockx_client "github.com/bogdanfinn/go-stockx-client"
+)
+
+// ProductBuilder builds realistic ProductDetails fixtures. Uuids are derived from the url key and size,
+// the same builder calls always produce the same product.
+type ProductBuilder struct {
+	product go_stockx_client.ProductDetails
+}
+
+func NewProduct(urlKey string) *ProductBuilder {
+	uuid := fixtureUUID(urlKey)
+	title := titleFromURLKey(urlKey)
+	releaseTime := time.Date(2021, time.March, 10, 0, 0, 0, 0, time.UTC)
+	imageUrl := fmt.Sprintf("https://images.stockx.com/images/%s-Product.jpg", strings.ReplaceAll(title, " ", "-"))
+
+	return &ProductBuilder{
+		product: go_stockx_client.ProductDetails{
+			ID:                uuid,
+			UUID:              uuid,
+			Brand:             strings.SplitN(title, " ", 2)[0],
+			Colorway:          "White/Black",
+			Minimumbid:        25,
+			Name:              title,
+			Releasedate:       releaseTime.Format("2006-01-02"),
+			ReleaseTime:       releaseTime,
+			Retailprice:       100,
+			SizeLocale:        "us",
+			SizeTitle:         "US M",
+			Shortdescription:  "DD1391-100",
+			Styleid:           "DD1391-100",
+			Title:             title,
+			ProductIdentifier: urlKey,
+			Imageurl:          imageUrl,
+			Smallimageurl:     imageUrl + "?w=300",
+			Thumburl:          imageUrl + "?w=140",
+			Gender:            "men",
+			Condition:         "New",
+			Productcategory:   "sneakers",
+			Year:              releaseTime.Year(),
+			Shipping: go_stockx_client.ProductDetailsShipping{
+				Totaldaystoship:        3,
+				Deliverydayslowerbound: 4,
+				Deliverydaysupperbound: 8,
+			},
+		},
+	}
+}
+
+func (b *ProductBuilder) WithTitle(title string) *ProductBuilder {
+	b.product.Title = title
+	b.product.Name = title
+	return b
+}
+
+func (b *ProductBuilder) WithBrand(brand string) *ProductBuilder {
+	b.product.Brand = brand
+	return b
+}
+
+func (b *ProductBuilder) WithColorway(colorway string) *ProductBuilder {
+	b.product.Colorway = colorway
+	return b
+}
+
+func (b *ProductBuilder) WithStyleID(styleID string) *ProductBuilder {
+	b.product.Styleid = styleID
+	b.product.Shortdescription = styleID
+	return b
+}
+
+func (b *ProductBuilder) WithRetailPrice(retailPrice int) *ProductBuilder {
+	b.product.Retailprice = retailPrice
+	return b
+}
+
+func (b *ProductBuilder) WithReleaseTime(releaseTime time.Time) *ProductBuilder {
+	b.product.ReleaseTime = releaseTime
+	b.product.Releasedate = releaseTime.Format("2006-01-02")
+	b.product.Year = releaseTime.Year()
+	return b
+}
+
+// WithVariant adds a size with its lowest ask and highest bid, zero means no asks or bids.
+func (b *ProductBuilder) WithVariant(size string, lowestAsk int, highestBid int, gtins ...string) *ProductBuilder {
+	uuid := fixtureUUID(b.product.ProductIdentifier + "/" + size)
+
+	b.product.Variants = append(b.product.Variants, go_stockx_client.ProductDetailsVariant{
+		UUID:            uuid,
+		Size:            size,
+		GTINs:           gtins,
+		Lowestask:       lowestAsk,
+		Highestbid:      highestBid,
+		Lowestaskfloat:  float64(lowestAsk),
+		Highestbidfloat: float64(highestBid),
+		Market: go_stockx_client.MarketData{
+			Skuuuid:         go_stockx_client.FlexString(uuid),
+			Productuuid:     go_stockx_client.FlexString(b.product.UUID),
+			Lowestask:       go_stockx_client.FlexInt(lowestAsk),
+			Lowestasksize:   go_stockx_client.FlexString(size),
+			Highestbid:      go_stockx_client.FlexInt(highestBid),
+			Highestbidsize:  go_stockx_client.FlexString(size),
+			Lowestaskfloat:  go_stockx_client.FlexFloat(lowestAsk),
+			Highestbidfloat: go_stockx_client.FlexFloat(highestBid),
+		},
+	})
+
+	return b
+}
+
+// Build returns the product, its lowest ask and highest bid are calculated from the variants.
+func (b *ProductBuilder) Build() *go_stockx_client.ProductDetails {
+	product := copyProduct(&b.product)
+
+	product.Lowestask, product.Highestbid = 0, 0
+	product.Market = go_stockx_client.MarketData{Productuuid: go_stockx_client.FlexString(product.UUID)}
+
+	for _, variant := range product.Variants {
+		if variant.Lowestask > 0 && (product.Lowestask == 0 || variant.Lowestask < product.Lowestask) {
+			product.Lowestask = variant.Lowestask
+			product.Market.Lowestasksize = go_stockx_client.FlexString(variant.Size)
+		}
+
+		if variant.Highestbid > product.Highestbid {
+			product.Highestbid = variant.Highestbid
+			product.Market.Highestbidsize = go_stockx_client.FlexString(variant.Size)
+		}
+	}
+
+	product.Lowestaskfloat = float64(product.Lowestask)
+	product.Highestbidfloat = float64(product.Highestbid)
+	product.Market.Lowestask = go_stockx_client.FlexInt(product.Lowestask)
+	product.Market.Highestbid = go_stockx_client.FlexInt(product.Highestbid)
+	product.Market.Lowestaskfloat = go_stockx_client.FlexFloat(product.Lowestaskfloat)
+	product.Market.Highestbidfloat = go_stockx_client.FlexFloat(product.Highestbidfloat)
+
+	return product
+}
+
+// NewSearchResult returns the search result stockx lists for product.
+func NewSearchResult(product *go_stockx_client.ProductDetails) go_stockx_client.SearchResultProduct {
+	return go_stockx_client.SearchResultProduct{
+		Brand:             product.Brand,
+		Colorway:          product.Colorway,
+		ImageUrl:          product.Thumburl,
+		Category:          product.Productcategory,
+		Description:       product.Shortdescription,
+		Title:             product.Title,
+		ProductIdentifier: product.ProductIdentifier,
+	}
+}
+
+func fixtureUUID(seed string) string {
+	sum := sha1.Sum([]byte(seed))
+	return fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
+
+func titleFromURLKey(urlKey string) string {
+	words := strings.Fields(strings.ReplaceAll(urlKey, "-", " "))
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+
+	return strings.Join(words, " ")
+}
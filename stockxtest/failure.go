@@ -0,0 +1,113 @@
+package stockxtest
+
+import (
+	"strings"
+	"time"
+)
+
+type FailureKind int
+
+const (
+	// FailureRateLimited answers with 429 and a Retry-After header.
+	FailureRateLimited FailureKind = iota
+	// FailureCaptcha answers with 403 and a PerimeterX captcha page.
+	FailureCaptcha
+	// FailureMalformedJSON answers with 200 and a truncated json body.
+	FailureMalformedJSON
+	// FailureSlow delays the regular response.
+	FailureSlow
+	// FailureCloudflare answers with 403 and a Cloudflare managed challenge page.
+	FailureCloudflare
+)
+
+const captchaPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Access to this page has been denied.</title>
+<script>
+window._pxAppId = 'PX16uD0kOF';
+window._pxJsClientSrc = '/16uD0kOF/init.js';
+window._pxHostUrl = '/16uD0kOF/xhr';
+</script>
+</head>
+<body>
+<div id="px-captcha"></div>
+<p>Press &amp; Hold to confirm you are a human (and not a bot).</p>
+<script src="https://captcha.px-cdn.net/PX16uD0kOF/captcha.js?a=c&m=0"></script>
+</body>
+</html>
+`
+
+const cloudflarePage = `<!DOCTYPE html>
+<html lang="en-US">
+<head>
+<title>Just a moment...</title>
+<meta http-equiv="Content-Type" content="text/html; charset=UTF-8">
+<meta name="robots" content="noindex,nofollow">
+</head>
+<body>
+<div class="main-wrapper" role="main">
+<div class="main-content">
+<h1 class="zone-name-title h1">stockx.com</h1>
+<h2 class="h2" id="challenge-running">Checking if the site connection is secure</h2>
+</div>
+</div>
+<script>
+(function(){window._cf_chl_opt={cvId: '2',cZone: 'stockx.com',cType: 'managed',cRay: '7f2e8c1d9a3b4c5d'};
+var a = document.createElement('script');a.src = '/cdn-cgi/challenge-platform/h/g/orchestrate/managed/v1?ray=7f2e8c1d9a3b4c5d';document.getElementsByTagName('head')[0].appendChild(a);})();
+</script>
+</body>
+</html>
+`
+
+const malformedJsonBody = `{"Product":{"id":"5e6a1e57-1c7d-435a-82bd-5666a13560fe","title":"Nike Dunk Lo`
+
+// Failure is a scripted failure served instead of (or, for FailureSlow, before) the regular response.
+type Failure struct {
+	Kind FailureKind
+	// Path restricts the failure to requests whose path starts with it, empty matches all requests.
+	Path string
+	// Times is the number of requests the failure is served for, zero or less means once.
+	Times int
+	// Delay is the delay of FailureSlow.
+	Delay time.Duration
+	// RetryAfter is the Retry-After header value of FailureRateLimited.
+	RetryAfter string
+}
+
+func RateLimited() Failure {
+	return Failure{Kind: FailureRateLimited, RetryAfter: "1"}
+}
+
+func Captcha() Failure {
+	return Failure{Kind: FailureCaptcha}
+}
+
+func Cloudflare() Failure {
+	return Failure{Kind: FailureCloudflare}
+}
+
+func MalformedJSON() Failure {
+	return Failure{Kind: FailureMalformedJSON}
+}
+
+func Slow(delay time.Duration) Failure {
+	return Failure{Kind: FailureSlow, Delay: delay}
+}
+
+// On restricts the failure to requests whose path starts with path, e.g. "/api/products".
+func (f Failure) On(path string) Failure {
+	f.Path = path
+	return f
+}
+
+// Repeat serves the failure for the next n matching requests.
+func (f Failure) Repeat(n int) Failure {
+	f.Times = n
+	return f
+}
+
+func (f Failure) matches(path string) bool {
+	return strings.HasPrefix(path, f.Path)
+}
@@ -0,0 +1,632 @@
+package stockxtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	go_stockx_client "github.com/bogdanfinn/go-stockx-client"
+)
+
+var _ go_stockx_client.Client = (*FakeClient)(nil)
+
+const fakeSalesPageSize = 20
+
+// Call is a method call received by the FakeClient.
+type Call struct {
+	Method string
+	Args   []interface{}
+	Time   time.Time
+}
+
+// FakeClient is an in-memory go_stockx_client.Client answering from the products, sales, order books and price
+// histories added to it. The Func fields replace the built-in behaviour of a method when set, calls are
+// recorded and errors injected with SetError or FailNext in both cases.
+type FakeClient struct {
+	SearchProductsFunc      func(query string, limit int) ([]go_stockx_client.SearchResultProduct, error)
+	GetProductFunc          func(productIdentifier string) (*go_stockx_client.ProductDetails, error)
+	GetProductByStyleIDFunc func(styleID string) (*go_stockx_client.ProductDetails, error)
+	GetProductByGTINFunc    func(gtin string) (*go_stockx_client.ProductDetails, *go_stockx_client.ProductDetailsVariant, error)
+	GetRelatedProductsFunc  func(productIdentifier string, limit int) ([]go_stockx_client.SearchResultProduct, error)
+	GetSalesFunc            func(productIdentifier string, variantUUID string, page int) ([]go_stockx_client.Sale, error)
+	GetOrderBookFunc        func(productIdentifier string, variantUUID string) (*go_stockx_client.OrderBook, error)
+	GetPriceHistoryFunc     func(productIdentifier string, numberOfPoints int, start time.Time, end time.Time, variantUUID string) ([]go_stockx_client.PricePoint, error)
+
+	lock         sync.Mutex
+	products     []go_stockx_client.ProductDetails
+	related      map[string][]go_stockx_client.SearchResultProduct
+	sales        map[string][]go_stockx_client.Sale
+	orderBooks   map[string]*go_stockx_client.OrderBook
+	priceHistory map[string][]go_stockx_client.PricePoint
+	errors       map[string]error
+	nextErrors   map[string][]error
+	calls        []Call
+	proxyUrl     string
+
+	identities    []go_stockx_client.BrowserIdentity
+	identityIndex int
+}
+
+func NewFakeClient(products ...*go_stockx_client.ProductDetails) *FakeClient {
+	f := &FakeClient{
+		related:      map[string][]go_stockx_client.SearchResultProduct{},
+		sales:        map[string][]go_stockx_client.Sale{},
+		orderBooks:   map[string]*go_stockx_client.OrderBook{},
+		priceHistory: map[string][]go_stockx_client.PricePoint{},
+		errors:       map[string]error{},
+		nextErrors:   map[string][]error{},
+		identities:   []go_stockx_client.BrowserIdentity{go_stockx_client.DefaultIdentity},
+	}
+
+	for _, product := range products {
+		f.AddProduct(product)
+	}
+
+	return f
+}
+
+// AddProduct makes a product available by its url key, uuid, id and the uuids of its variants.
+func (f *FakeClient) AddProduct(product *go_stockx_client.ProductDetails) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.products = append(f.products, *copyProduct(product))
+}
+
+// SetRelatedProducts sets the related products of the product with the given identifier.
+func (f *FakeClient) SetRelatedProducts(productIdentifier string, related []go_stockx_client.SearchResultProduct) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.related[f.productKey(productIdentifier)] = related
+}
+
+// SetSales sets the sales of a product, newest first. GetSales filters them by variant and pages them like stockx.
+func (f *FakeClient) SetSales(productIdentifier string, sales []go_stockx_client.Sale) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.sales[f.productKey(productIdentifier)] = sales
+}
+
+// SetOrderBook sets the product wide order book, without one GetOrderBook derives a single level per side
+// from the lowest ask and highest bid of every variant.
+func (f *FakeClient) SetOrderBook(productIdentifier string, orderBook *go_stockx_client.OrderBook) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.orderBooks[f.productKey(productIdentifier)] = orderBook
+}
+
+func (f *FakeClient) SetPriceHistory(productIdentifier string, points []go_stockx_client.PricePoint) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.priceHistory[f.productKey(productIdentifier)] = points
+}
+
+// SetIdentities sets the identities RotateIdentity cycles through, starting with the first one.
+func (f *FakeClient) SetIdentities(identities ...go_stockx_client.BrowserIdentity) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if len(identities) == 0 {
+		identities = []go_stockx_client.BrowserIdentity{go_stockx_client.DefaultIdentity}
+	}
+
+	f.identities = identities
+	f.identityIndex = 0
+}
+
+// SetError makes every call of method (e.g. "GetProduct") fail with err, a nil err removes the error again.
+func (f *FakeClient) SetError(method string, err error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if err == nil {
+		delete(f.errors, method)
+		return
+	}
+
+	f.errors[method] = err
+}
+
+// FailNext makes the next calls of method fail with the given errors, one error per call.
+func (f *FakeClient) FailNext(method string, errs ...error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.nextErrors[method] = append(f.nextErrors[method], errs...)
+}
+
+func (f *FakeClient) Calls() []Call {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return append([]Call(nil), f.calls...)
+}
+
+func (f *FakeClient) CallsTo(method string) []Call {
+	var calls []Call
+	for _, call := range f.Calls() {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
+}
+
+// Reset forgets all recorded calls and injected errors, products and other data are kept.
+func (f *FakeClient) Reset() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.calls = nil
+	f.errors = map[string]error{}
+	f.nextErrors = map[string][]error{}
+}
+
+func (f *FakeClient) SearchProducts(query string, limit int) ([]go_stockx_client.SearchResultProduct, error) {
+	if err := f.record("SearchProducts", query, limit); err != nil {
+		return nil, err
+	}
+
+	if f.SearchProductsFunc != nil {
+		return f.SearchProductsFunc(query, limit)
+	}
+
+	return f.searchProducts(query, limit), nil
+}
+
+func (f *FakeClient) SearchProductsResult(query string, limit int) (*go_stockx_client.Result[[]go_stockx_client.SearchResultProduct], error) {
+	if err := f.record("SearchProductsResult", query, limit); err != nil {
+		return errorResult[[]go_stockx_client.SearchResultProduct]("/api/browse", err), err
+	}
+
+	var value []go_stockx_client.SearchResultProduct
+	if f.SearchProductsFunc != nil {
+		var err error
+		value, err = f.SearchProductsFunc(query, limit)
+		if err != nil {
+			return errorResult[[]go_stockx_client.SearchResultProduct]("/api/browse", err), err
+		}
+	} else {
+		value = f.searchProducts(query, limit)
+	}
+
+	return newResult(value), nil
+}
+
+func (f *FakeClient) GetProduct(productIdentifier string) (*go_stockx_client.ProductDetails, error) {
+	if err := f.record("GetProduct", productIdentifier); err != nil {
+		return nil, err
+	}
+
+	if f.GetProductFunc != nil {
+		return f.GetProductFunc(productIdentifier)
+	}
+
+	return f.getProduct(productIdentifier)
+}
+
+// GetProductResult returns a partial result together with the error like the real client does, see errorResult.
+func (f *FakeClient) GetProductResult(productIdentifier string) (*go_stockx_client.Result[*go_stockx_client.ProductDetails], error) {
+	if err := f.record("GetProductResult", productIdentifier); err != nil {
+		return errorResult[*go_stockx_client.ProductDetails]("/api/products/"+productIdentifier, err), err
+	}
+
+	getProduct := f.getProduct
+	if f.GetProductFunc != nil {
+		getProduct = f.GetProductFunc
+	}
+
+	product, err := getProduct(productIdentifier)
+	if err != nil {
+		return errorResult[*go_stockx_client.ProductDetails]("/api/products/"+productIdentifier, err), err
+	}
+
+	return newResult(product), nil
+}
+
+func (f *FakeClient) GetProductByStyleID(styleID string) (*go_stockx_client.ProductDetails, error) {
+	if err := f.record("GetProductByStyleID", styleID); err != nil {
+		return nil, err
+	}
+
+	if f.GetProductByStyleIDFunc != nil {
+		return f.GetProductByStyleIDFunc(styleID)
+	}
+
+	var matches []go_stockx_client.ProductDetails
+	for _, product := range f.allProducts() {
+		if go_stockx_client.MatchesStyleID(product.Styleid, styleID) {
+			matches = append(matches, product)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("%w: no product with style id %s", go_stockx_client.ErrNotFound, styleID)
+	case 1:
+		return copyProduct(&matches[0]), nil
+	default:
+		return nil, fmt.Errorf("%w: style id %s matches %d products", go_stockx_client.ErrAmbiguous, styleID, len(matches))
+	}
+}
+
+func (f *FakeClient) GetProductByGTIN(gtin string) (*go_stockx_client.ProductDetails, *go_stockx_client.ProductDetailsVariant, error) {
+	if err := f.record("GetProductByGTIN", gtin); err != nil {
+		return nil, nil, err
+	}
+
+	if f.GetProductByGTINFunc != nil {
+		return f.GetProductByGTINFunc(gtin)
+	}
+
+	wanted := go_stockx_client.NormalizeGTIN(gtin)
+
+	for _, product := range f.allProducts() {
+		for i, variant := range product.Variants {
+			for _, variantGtin := range variant.GTINs {
+				if wanted != "" && go_stockx_client.NormalizeGTIN(variantGtin) == wanted {
+					details := copyProduct(&product)
+					return details, &details.Variants[i], nil
+				}
+			}
+		}
+	}
+
+	return nil, nil, fmt.Errorf("%w: no product with gtin %s", go_stockx_client.ErrNotFound, gtin)
+}
+
+func (f *FakeClient) GetRelatedProducts(productIdentifier string, limit int) ([]go_stockx_client.SearchResultProduct, error) {
+	if err := f.record("GetRelatedProducts", productIdentifier, limit); err != nil {
+		return nil, err
+	}
+
+	if f.GetRelatedProductsFunc != nil {
+		return f.GetRelatedProductsFunc(productIdentifier, limit)
+	}
+
+	if limit <= 0 {
+		limit = go_stockx_client.DefaultRelatedProductsLimit
+	}
+
+	f.lock.Lock()
+	related := f.related[f.productKey(productIdentifier)]
+	f.lock.Unlock()
+
+	if len(related) > limit {
+		related = related[:limit]
+	}
+
+	return append([]go_stockx_client.SearchResultProduct(nil), related...), nil
+}
+
+func (f *FakeClient) GetSales(productIdentifier string, variantUUID string, page int) ([]go_stockx_client.Sale, error) {
+	if err := f.record("GetSales", productIdentifier, variantUUID, page); err != nil {
+		return nil, err
+	}
+
+	if f.GetSalesFunc != nil {
+		return f.GetSalesFunc(productIdentifier, variantUUID, page)
+	}
+
+	if page < 1 {
+		page = 1
+	}
+
+	f.lock.Lock()
+	allSales := f.sales[f.productKey(productIdentifier)]
+	f.lock.Unlock()
+
+	var sales []go_stockx_client.Sale
+	for _, sale := range allSales {
+		if variantUUID == "" || sale.VariantUUID == variantUUID {
+			sales = append(sales, sale)
+		}
+	}
+
+	start := (page - 1) * fakeSalesPageSize
+	if start >= len(sales) {
+		return nil, nil
+	}
+
+	end := start + fakeSalesPageSize
+	if end > len(sales) {
+		end = len(sales)
+	}
+
+	return append([]go_stockx_client.Sale(nil), sales[start:end]...), nil
+}
+
+func (f *FakeClient) GetOrderBook(productIdentifier string, variantUUID string) (*go_stockx_client.OrderBook, error) {
+	if err := f.record("GetOrderBook", productIdentifier, variantUUID); err != nil {
+		return nil, err
+	}
+
+	if f.GetOrderBookFunc != nil {
+		return f.GetOrderBookFunc(productIdentifier, variantUUID)
+	}
+
+	f.lock.Lock()
+	orderBook, ok := f.orderBooks[f.productKey(productIdentifier)]
+	f.lock.Unlock()
+
+	if !ok {
+		product, err := f.getProduct(productIdentifier)
+		if err != nil {
+			return nil, err
+		}
+
+		orderBook = orderBookFromVariants(product)
+	}
+
+	filtered := &go_stockx_client.OrderBook{}
+	for _, level := range orderBook.Asks {
+		if variantUUID == "" || level.VariantUUID == variantUUID {
+			filtered.Asks = append(filtered.Asks, level)
+		}
+	}
+
+	for _, level := range orderBook.Bids {
+		if variantUUID == "" || level.VariantUUID == variantUUID {
+			filtered.Bids = append(filtered.Bids, level)
+		}
+	}
+
+	return filtered, nil
+}
+
+func (f *FakeClient) GetPriceHistory(productIdentifier string, numberOfPoints int, start time.Time, end time.Time, variantUUID string) ([]go_stockx_client.PricePoint, error) {
+	if err := f.record("GetPriceHistory", productIdentifier, numberOfPoints, start, end, variantUUID); err != nil {
+		return nil, err
+	}
+
+	if f.GetPriceHistoryFunc != nil {
+		return f.GetPriceHistoryFunc(productIdentifier, numberOfPoints, start, end, variantUUID)
+	}
+
+	identifier := productIdentifier
+	if variantUUID != "" {
+		identifier = variantUUID
+	}
+
+	f.lock.Lock()
+	allPoints := f.priceHistory[f.productKey(identifier)]
+	f.lock.Unlock()
+
+	var points []go_stockx_client.PricePoint
+	for _, point := range allPoints {
+		if !point.Time.Before(start) && !point.Time.After(end) {
+			points = append(points, point)
+		}
+	}
+
+	return points, nil
+}
+
+func (f *FakeClient) SetProxy(proxyUrl string) error {
+	if err := f.record("SetProxy", proxyUrl); err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.proxyUrl = proxyUrl
+
+	return nil
+}
+
+func (f *FakeClient) GetProxy() string {
+	_ = f.record("GetProxy")
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return f.proxyUrl
+}
+
+func (f *FakeClient) RotateIdentity() (go_stockx_client.BrowserIdentity, error) {
+	err := f.record("RotateIdentity")
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if err != nil {
+		return f.identities[f.identityIndex], err
+	}
+
+	f.identityIndex = (f.identityIndex + 1) % len(f.identities)
+
+	return f.identities[f.identityIndex], nil
+}
+
+func (f *FakeClient) GetIdentity() go_stockx_client.BrowserIdentity {
+	_ = f.record("GetIdentity")
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return f.identities[f.identityIndex]
+}
+
+// WithContext returns the fake itself, calls of the returned client are recorded on f.
+func (f *FakeClient) WithContext(ctx context.Context) go_stockx_client.Client {
+	_ = f.record("WithContext", ctx)
+
+	return f
+}
+
+// record stores the call and returns the error injected for it, if any.
+func (f *FakeClient) record(method string, args ...interface{}) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.calls = append(f.calls, Call{Method: method, Args: args, Time: time.Now()})
+
+	if next := f.nextErrors[method]; len(next) > 0 {
+		f.nextErrors[method] = next[1:]
+		return next[0]
+	}
+
+	return f.errors[method]
+}
+
+func (f *FakeClient) allProducts() []go_stockx_client.ProductDetails {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return append([]go_stockx_client.ProductDetails(nil), f.products...)
+}
+
+func (f *FakeClient) searchProducts(query string, limit int) []go_stockx_client.SearchResultProduct {
+	terms := strings.Fields(strings.ToLower(strings.ReplaceAll(query, "+", " ")))
+
+	var results []go_stockx_client.SearchResultProduct
+	for _, product := range f.allProducts() {
+		if limit > 0 && len(results) >= limit {
+			break
+		}
+
+		text := strings.ToLower(strings.Join([]string{product.Title, product.Brand, product.Colorway, product.Styleid, product.ProductIdentifier}, " "))
+		for _, variant := range product.Variants {
+			text += " " + strings.Join(variant.GTINs, " ")
+		}
+
+		matches := true
+		for _, term := range terms {
+			if !strings.Contains(text, term) {
+				matches = false
+				break
+			}
+		}
+
+		if matches {
+			results = append(results, NewSearchResult(&product))
+		}
+	}
+
+	return results
+}
+
+func (f *FakeClient) getProduct(productIdentifier string) (*go_stockx_client.ProductDetails, error) {
+	ref, err := go_stockx_client.ParseProductRef(productIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, product := range f.allProducts() {
+		if productMatches(product, ref.Identifier) {
+			return copyProduct(&product), nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", go_stockx_client.ErrNotFound, ref.Identifier)
+}
+
+// productKey resolves any identifier of a known product to its uuid, so data set by url key can be
+// requested by uuid and vice versa. Callers must hold the lock.
+func (f *FakeClient) productKey(productIdentifier string) string {
+	identifier := productIdentifier
+	if ref, err := go_stockx_client.ParseProductRef(productIdentifier); err == nil {
+		identifier = ref.Identifier
+	}
+
+	for _, product := range f.products {
+		if productMatches(product, identifier) {
+			return product.UUID
+		}
+	}
+
+	return strings.ToLower(identifier)
+}
+
+func productMatches(product go_stockx_client.ProductDetails, identifier string) bool {
+	for _, candidate := range []string{product.ProductIdentifier, product.UUID, product.ID} {
+		if candidate != "" && strings.EqualFold(candidate, identifier) {
+			return true
+		}
+	}
+
+	for _, variant := range product.Variants {
+		if strings.EqualFold(variant.UUID, identifier) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func orderBookFromVariants(product *go_stockx_client.ProductDetails) *go_stockx_client.OrderBook {
+	orderBook := &go_stockx_client.OrderBook{}
+
+	for _, variant := range product.Variants {
+		if variant.Lowestask > 0 {
+			orderBook.Asks = append(orderBook.Asks, go_stockx_client.PriceLevel{Price: float64(variant.Lowestask), Quantity: 1, Size: variant.Size, VariantUUID: variant.UUID})
+		}
+
+		if variant.Highestbid > 0 {
+			orderBook.Bids = append(orderBook.Bids, go_stockx_client.PriceLevel{Price: float64(variant.Highestbid), Quantity: 1, Size: variant.Size, VariantUUID: variant.UUID})
+		}
+	}
+
+	sort.Slice(orderBook.Asks, func(i, j int) bool { return orderBook.Asks[i].Price < orderBook.Asks[j].Price })
+	sort.Slice(orderBook.Bids, func(i, j int) bool { return orderBook.Bids[i].Price > orderBook.Bids[j].Price })
+
+	return orderBook
+}
+
+// newResult returns a successful result, Raw holds the json encoded value as the fake has no stockx response.
+func newResult[T any](value T) *go_stockx_client.Result[T] {
+	raw, _ := json.Marshal(value)
+
+	return &go_stockx_client.Result[T]{
+		Value:     value,
+		Raw:       raw,
+		Status:    http.StatusOK,
+		Header:    map[string][]string{"Content-Type": {"application/json"}},
+		FetchedAt: time.Now(),
+	}
+}
+
+// errorResult returns the partial result the real client returns together with err whenever stockx answered:
+// a 404 response for ErrNotFound and the challenge response for a *go_stockx_client.ChallengeError. Other
+// errors are treated like requests without response and return nil, as the real client does.
+func errorResult[T any](path string, err error) *go_stockx_client.Result[T] {
+	var challenge *go_stockx_client.ChallengeError
+
+	switch {
+	case errors.As(err, &challenge):
+		return &go_stockx_client.Result[T]{
+			Raw:       challenge.Body,
+			Status:    challenge.StatusCode,
+			Header:    challenge.Header,
+			FetchedAt: time.Now(),
+		}
+	case errors.Is(err, go_stockx_client.ErrNotFound):
+		return &go_stockx_client.Result[T]{
+			Raw:       notFoundBody(path),
+			Status:    http.StatusNotFound,
+			Header:    map[string][]string{"Content-Type": {"application/json"}},
+			FetchedAt: time.Now(),
+		}
+	default:
+		return nil
+	}
+}
+
+func copyProduct(product *go_stockx_client.ProductDetails) *go_stockx_client.ProductDetails {
+	copied := *product
+	copied.Variants = append([]go_stockx_client.ProductDetailsVariant(nil), product.Variants...)
+
+	return &copied
+}
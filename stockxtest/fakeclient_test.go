@@ -0,0 +1,224 @@
+package stockxtest_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	go_stockx_client "github.com/bogdanfinn/go-stockx-client"
+	"github.com/bogdanfinn/go-stockx-client/stockxtest"
+)
+
+func testProducts() (*go_stockx_client.ProductDetails, *go_stockx_client.ProductDetails) {
+	dunk := stockxtest.NewProduct("nike-dunk-low-retro-white-black-2021").
+		WithStyleID("DD1391-100/DD1503-101").
+		WithVariant("9", 120, 100, "194502876000").
+		WithVariant("10", 115, 105, "00194502876017").
+		Build()
+
+	jordan := stockxtest.NewProduct("air-jordan-1-retro-high-og-chicago-lost-and-found").
+		WithBrand("Jordan").
+		WithStyleID("DZ5485-612").
+		WithVariant("10", 290, 250).
+		Build()
+
+	return dunk, jordan
+}
+
+func TestProductBuilder(t *testing.T) {
+	dunk, _ := testProducts()
+
+	if dunk.Lowestask != 115 || dunk.Highestbid != 105 || dunk.Market.Lowestasksize != "10" {
+		t.Fatalf("unexpected market summary: %+v", dunk.Market)
+	}
+
+	for _, variant := range dunk.Variants {
+		if string(variant.Market.Productuuid) != dunk.UUID {
+			t.Fatalf("expected the market of size %s to reference the product uuid, got %s", variant.Size, variant.Market.Productuuid)
+		}
+
+		if string(variant.Market.Skuuuid) != variant.UUID {
+			t.Fatalf("expected the market of size %s to reference the variant uuid, got %s", variant.Size, variant.Market.Skuuuid)
+		}
+	}
+
+	again, _ := testProducts()
+	if again.Variants[0].UUID != dunk.Variants[0].UUID {
+		t.Fatalf("expected the builder to be deterministic")
+	}
+}
+
+func TestFakeClientLookups(t *testing.T) {
+	dunk, jordan := testProducts()
+	client := stockxtest.NewFakeClient(dunk, jordan)
+
+	for _, identifier := range []string{dunk.ProductIdentifier, dunk.UUID, dunk.Variants[1].UUID, "https://stockx.com/de-de/" + dunk.ProductIdentifier} {
+		product, err := client.GetProduct(identifier)
+		if err != nil {
+			t.Fatalf("failed to load product by %s: %v", identifier, err)
+		}
+
+		if product.UUID != dunk.UUID {
+			t.Fatalf("unexpected product for %s: %s", identifier, product.UUID)
+		}
+	}
+
+	// the second style id of a slash separated list matches as well, like in the real client
+	product, err := client.GetProductByStyleID("dd1503 101")
+	if err != nil || product.UUID != dunk.UUID {
+		t.Fatalf("style id lookup failed: %v", err)
+	}
+
+	product, variant, err := client.GetProductByGTIN("194502876017")
+	if err != nil {
+		t.Fatalf("gtin lookup failed: %v", err)
+	}
+
+	if product.UUID != dunk.UUID || variant.Size != "10" {
+		t.Fatalf("unexpected gtin lookup result: %s %+v", product.UUID, variant)
+	}
+
+	results, err := client.SearchProducts("jordan chicago", 10)
+	if err != nil || len(results) != 1 || results[0].ProductIdentifier != jordan.ProductIdentifier {
+		t.Fatalf("unexpected search results: %+v, %v", results, err)
+	}
+
+	if _, err = client.GetProduct("unknown-product"); !errors.Is(err, go_stockx_client.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	if _, err = client.GetProductByStyleID("XX0000-000"); !errors.Is(err, go_stockx_client.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestFakeClientMarketData(t *testing.T) {
+	dunk, _ := testProducts()
+	client := stockxtest.NewFakeClient(dunk)
+
+	orderBook, err := client.GetOrderBook(dunk.UUID, dunk.Variants[0].UUID)
+	if err != nil {
+		t.Fatalf("failed to load order book: %v", err)
+	}
+
+	if len(orderBook.Asks) != 1 || orderBook.Asks[0].Price != 120 || len(orderBook.Bids) != 1 || orderBook.Bids[0].Price != 100 {
+		t.Fatalf("unexpected order book derived from the variants: %+v", orderBook)
+	}
+
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	client.SetSales(dunk.ProductIdentifier, []go_stockx_client.Sale{{Amount: 118, Size: "10", Time: now}})
+	client.SetPriceHistory(dunk.ProductIdentifier, []go_stockx_client.PricePoint{
+		{Time: now.AddDate(0, 0, -10), Price: 130, Currency: "EUR"},
+		{Time: now, Price: 118, Currency: "EUR"},
+	})
+
+	// data set by url key is found by uuid
+	sales, err := client.GetSales(dunk.UUID, "", 1)
+	if err != nil || len(sales) != 1 || sales[0].Amount != 118 {
+		t.Fatalf("unexpected sales: %+v, %v", sales, err)
+	}
+
+	points, err := client.GetPriceHistory(dunk.UUID, 0, now.AddDate(0, 0, -1), now, "")
+	if err != nil || len(points) != 1 || points[0].Price != 118 {
+		t.Fatalf("unexpected price history: %+v, %v", points, err)
+	}
+}
+
+func TestFakeClientErrorsAndCalls(t *testing.T) {
+	dunk, _ := testProducts()
+	client := stockxtest.NewFakeClient(dunk)
+
+	errBlocked := errors.New("blocked")
+
+	client.FailNext("GetProduct", errBlocked)
+
+	if _, err := client.GetProduct(dunk.UUID); !errors.Is(err, errBlocked) {
+		t.Fatalf("expected the injected error, got %v", err)
+	}
+
+	if _, err := client.GetProduct(dunk.UUID); err != nil {
+		t.Fatalf("expected FailNext to fail a single call, got %v", err)
+	}
+
+	client.SetError("SearchProducts", errBlocked)
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.SearchProducts("dunk", 10); !errors.Is(err, errBlocked) {
+			t.Fatalf("expected the injected error, got %v", err)
+		}
+	}
+
+	client.GetProductFunc = func(productIdentifier string) (*go_stockx_client.ProductDetails, error) {
+		return nil, go_stockx_client.ErrAmbiguous
+	}
+
+	if _, err := client.GetProduct(dunk.UUID); !errors.Is(err, go_stockx_client.ErrAmbiguous) {
+		t.Fatalf("expected the Func override to be used, got %v", err)
+	}
+
+	calls := client.CallsTo("GetProduct")
+	if len(calls) != 3 || calls[0].Args[0] != dunk.UUID {
+		t.Fatalf("unexpected recorded calls: %+v", calls)
+	}
+
+	client.Reset()
+
+	if len(client.Calls()) != 0 {
+		t.Fatalf("expected Reset to forget all calls")
+	}
+
+	if _, err := client.SearchProducts("dunk", 10); err != nil {
+		t.Fatalf("expected Reset to remove injected errors, got %v", err)
+	}
+}
+
+func TestFakeClientResults(t *testing.T) {
+	dunk, _ := testProducts()
+	client := stockxtest.NewFakeClient(dunk)
+
+	result, err := client.GetProductResult(dunk.ProductIdentifier)
+	if err != nil || result.Value.UUID != dunk.UUID || result.Status != http.StatusOK || result.Header.Get("Content-Type") != "application/json" || len(result.Raw) == 0 {
+		t.Fatalf("unexpected result: %+v, %v", result, err)
+	}
+
+	// like the real client, responses received together with an error are returned in the result
+	result, err = client.GetProductResult("unknown-product")
+	if !errors.Is(err, go_stockx_client.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	if result == nil || result.Value != nil || result.Status != http.StatusNotFound || len(result.Raw) == 0 {
+		t.Fatalf("expected a not found result, got %+v", result)
+	}
+
+	challenge := &go_stockx_client.ChallengeError{
+		Kind:       go_stockx_client.ChallengePerimeterX,
+		StatusCode: http.StatusForbidden,
+		Header:     map[string][]string{"Content-Type": {"text/html"}},
+		Body:       []byte("<html>captcha</html>"),
+	}
+
+	client.FailNext("GetProductResult", challenge, errors.New("connection reset"))
+
+	result, err = client.GetProductResult(dunk.ProductIdentifier)
+	if !errors.Is(err, go_stockx_client.ErrChallenge) {
+		t.Fatalf("expected a challenge error, got %v", err)
+	}
+
+	if result == nil || result.Status != http.StatusForbidden || string(result.Raw) != "<html>captcha</html>" || result.Header.Get("Content-Type") != "text/html" {
+		t.Fatalf("expected the challenge response in the result, got %+v", result)
+	}
+
+	// errors without a response return no result
+	if result, err = client.GetProductResult(dunk.ProductIdentifier); err == nil || result != nil {
+		t.Fatalf("expected no result for an error without response, got %+v, %v", result, err)
+	}
+
+	client.SetError("SearchProductsResult", challenge)
+
+	searchResult, err := client.SearchProductsResult("dunk", 10)
+	if err == nil || searchResult == nil || searchResult.Status != http.StatusForbidden {
+		t.Fatalf("expected the challenge response in the search result, got %+v, %v", searchResult, err)
+	}
+}
@@ -0,0 +1,70 @@
+package stockxtest
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	DefaultProductURLKey  = "nike-dunk-low-retro-white-black-2021"
+	DefaultProductUUID    = "5e6a1e57-1c7d-435a-82bd-5666a13560fe"
+	DefaultProductStyleID = "DD1391-100"
+)
+
+//go:embed fixtures/product.json
+var defaultProductFixture []byte
+
+//go:embed fixtures/search.json
+var defaultSearchFixture []byte
+
+// DefaultProductFixture returns the product details response served for DefaultProductURLKey and DefaultProductUUID.
+func DefaultProductFixture() []byte {
+	return append([]byte(nil), defaultProductFixture...)
+}
+
+// DefaultSearchFixture returns the search response served for every query without its own fixture.
+func DefaultSearchFixture() []byte {
+	return append([]byte(nil), defaultSearchFixture...)
+}
+
+// productResponses returns the responses a product fixture is served with, keyed by identifier: the fixture
+// itself for the product id, uuid and url key and, like stockx does, a variant response without children
+// for the uuid of every child.
+func productResponses(body []byte) (map[string][]byte, error) {
+	response := struct {
+		Product struct {
+			ID       string                     `json:"id"`
+			UUID     string                     `json:"uuid"`
+			Urlkey   string                     `json:"urlKey"`
+			Children map[string]json.RawMessage `json:"children"`
+		} `json:"Product"`
+	}{}
+
+	err := json.Unmarshal(body, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode product fixture: %w", err)
+	}
+
+	responses := map[string][]byte{}
+	for _, identifier := range []string{response.Product.ID, response.Product.UUID, response.Product.Urlkey} {
+		if identifier != "" {
+			responses[identifier] = body
+		}
+	}
+
+	if len(responses) == 0 {
+		return nil, fmt.Errorf("product fixture has neither id, uuid nor urlKey")
+	}
+
+	for key, child := range response.Product.Children {
+		childResponse, err := json.Marshal(map[string]json.RawMessage{"Product": child})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode variant %s of product fixture: %w", key, err)
+		}
+
+		responses[key] = childResponse
+	}
+
+	return responses, nil
+}
@@ -0,0 +1,289 @@
+package stockxtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	go_stockx_client "github.com/bogdanfinn/go-stockx-client"
+)
+
+// Request is a request received by the Server.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Time   time.Time
+}
+
+type response struct {
+	status int
+	body   []byte
+}
+
+// Server is a fake stockx serving "/", "/api/browse" and "/api/products/{id}" from fixtures.
+type Server struct {
+	*httptest.Server
+
+	lock           sync.Mutex
+	products       map[string][]byte
+	searches       map[string][]byte
+	responses      map[string]response
+	failures       []Failure
+	requests       []Request
+	defaultSearch  []byte
+	homepageStatus int
+}
+
+// NewServer starts a fake stockx serving the default fixtures, see DefaultProductFixture and DefaultSearchFixture.
+// The server has to be closed by the caller.
+func NewServer() *Server {
+	s := &Server{
+		products:       map[string][]byte{},
+		searches:       map[string][]byte{},
+		responses:      map[string]response{},
+		defaultSearch:  DefaultSearchFixture(),
+		homepageStatus: http.StatusOK,
+	}
+
+	err := s.AddProduct(DefaultProductFixture())
+	if err != nil {
+		panic(fmt.Sprintf("stockxtest: invalid default product fixture: %v", err))
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// ClientOptions returns the options to create a go_stockx_client.Client talking to this server over plain net/http.
+func (s *Server) ClientOptions() []go_stockx_client.ClientOption {
+	return []go_stockx_client.ClientOption{
+		go_stockx_client.WithBaseURL(s.URL),
+		go_stockx_client.WithTransport(go_stockx_client.NewNetHttpTransport(30 * time.Second)),
+	}
+}
+
+// AddProduct serves a product details response for the product id, uuid and url key found in body. The uuids
+// of its children are served the child alone, the client then loads the product by the child's parentUuid.
+func (s *Server) AddProduct(body []byte) error {
+	responses, err := productResponses(body)
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for identifier, response := range responses {
+		s.products[strings.ToLower(identifier)] = response
+	}
+
+	return nil
+}
+
+// AddProductFile serves the product details response stored in the file at path, see AddProduct.
+func (s *Server) AddProductFile(path string) error {
+	body, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read product fixture: %w", err)
+	}
+
+	return s.AddProduct(body)
+}
+
+// AddSearch serves body for the given search query, other queries get the default search response.
+func (s *Server) AddSearch(query string, body []byte) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.searches[normalizeQuery(query)] = body
+}
+
+// SetDefaultSearch replaces the response served for queries without their own search fixture.
+func (s *Server) SetDefaultSearch(body []byte) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.defaultSearch = body
+}
+
+// SetResponse serves status and body for all requests to path, regardless of the query.
+// Use it for endpoints without built-in fixtures like "/api/products/{id}/activity".
+func (s *Server) SetResponse(path string, status int, body []byte) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.responses[path] = response{status: status, body: body}
+}
+
+// SetHomepageStatus sets the status code of "/", which the client requests once during initialization.
+func (s *Server) SetHomepageStatus(status int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.homepageStatus = status
+}
+
+// Fail queues a scripted failure, failures are served in the order they were queued.
+func (s *Server) Fail(failures ...Failure) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, failure := range failures {
+		if failure.Times <= 0 {
+			failure.Times = 1
+		}
+
+		s.failures = append(s.failures, failure)
+	}
+}
+
+// Requests returns all requests received so far.
+func (s *Server) Requests() []Request {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+// RequestsTo returns all requests received so far whose path starts with path.
+func (s *Server) RequestsTo(path string) []Request {
+	var requests []Request
+	for _, request := range s.Requests() {
+		if strings.HasPrefix(request.Path, path) {
+			requests = append(requests, request)
+		}
+	}
+
+	return requests
+}
+
+// Reset forgets all received requests and queued failures, fixtures are kept.
+func (s *Server) Reset() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.requests = nil
+	s.failures = nil
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	failure, ok := s.record(r)
+
+	if ok {
+		switch failure.Kind {
+		case FailureRateLimited:
+			if failure.RetryAfter != "" {
+				w.Header().Set("Retry-After", failure.RetryAfter)
+			}
+			writeBody(w, http.StatusTooManyRequests, "application/json", []byte(`{"error":"Too Many Requests"}`))
+			return
+		case FailureCaptcha:
+			writeBody(w, http.StatusForbidden, "text/html; charset=utf-8", []byte(captchaPage))
+			return
+		case FailureCloudflare:
+			w.Header().Set("Server", "cloudflare")
+			w.Header().Set("cf-mitigated", "challenge")
+			writeBody(w, http.StatusForbidden, "text/html; charset=UTF-8", []byte(cloudflarePage))
+			return
+		case FailureMalformedJSON:
+			writeBody(w, http.StatusOK, "application/json", []byte(malformedJsonBody))
+			return
+		case FailureSlow:
+			select {
+			case <-time.After(failure.Delay):
+			case <-r.Context().Done():
+				return
+			}
+		}
+	}
+
+	status, body := s.respond(r)
+
+	contentType := "application/json"
+	if r.URL.Path == "/" {
+		contentType = "text/html; charset=utf-8"
+	}
+
+	writeBody(w, status, contentType, body)
+}
+
+// record stores the request and pops the first queued failure matching it.
+func (s *Server) record(r *http.Request) (Failure, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Time:   time.Now(),
+	})
+
+	for i, failure := range s.failures {
+		if !failure.matches(r.URL.Path) {
+			continue
+		}
+
+		s.failures[i].Times--
+		if s.failures[i].Times <= 0 {
+			s.failures = append(s.failures[:i], s.failures[i+1:]...)
+		}
+
+		return failure, true
+	}
+
+	return Failure{}, false
+}
+
+func (s *Server) respond(r *http.Request) (int, []byte) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	path := r.URL.Path
+
+	if configured, ok := s.responses[path]; ok {
+		return configured.status, configured.body
+	}
+
+	switch {
+	case path == "/":
+		return s.homepageStatus, []byte("<!DOCTYPE html><html><head><title>StockX</title></head><body></body></html>")
+	case path == "/api/browse":
+		if body, ok := s.searches[normalizeQuery(r.URL.Query().Get("_search"))]; ok {
+			return http.StatusOK, body
+		}
+
+		return http.StatusOK, s.defaultSearch
+	case strings.HasPrefix(path, "/api/products/") && !strings.Contains(strings.TrimPrefix(path, "/api/products/"), "/"):
+		if body, ok := s.products[strings.ToLower(strings.TrimPrefix(path, "/api/products/"))]; ok {
+			return http.StatusOK, body
+		}
+	}
+
+	return http.StatusNotFound, notFoundBody(path)
+}
+
+func notFoundBody(path string) []byte {
+	body, _ := json.Marshal(map[string]string{"error": "Not Found", "path": path})
+	return body
+}
+
+func normalizeQuery(query string) string {
+	return strings.ToLower(strings.Join(strings.Fields(strings.ReplaceAll(query, "+", " ")), " "))
+}
+
+func writeBody(w http.ResponseWriter, status int, contentType string, body []byte) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,171 @@
+package stockxtest_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	go_stockx_client "github.com/bogdanfinn/go-stockx-client"
+	"github.com/bogdanfinn/go-stockx-client/stockxtest"
+)
+
+const (
+	defaultVariantUUID = "2b1fb7d8-6b9c-4b4d-8d9f-6c8b2c1e7a02"
+	defaultVariantGTIN = "194502876017"
+)
+
+func newClient(t *testing.T, server *stockxtest.Server, options ...go_stockx_client.ClientOption) go_stockx_client.Client {
+	t.Helper()
+
+	client, err := go_stockx_client.NewClient("EUR", "DE", go_stockx_client.NewNoopLogger(), false, append(server.ClientOptions(), options...)...)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	return client
+}
+
+func TestServerServesDefaultProduct(t *testing.T) {
+	server := stockxtest.NewServer()
+	defer server.Close()
+
+	client := newClient(t, server)
+
+	for _, identifier := range []string{stockxtest.DefaultProductURLKey, stockxtest.DefaultProductUUID, defaultVariantUUID} {
+		product, err := client.GetProduct(identifier)
+		if err != nil {
+			t.Fatalf("failed to load product by %s: %v", identifier, err)
+		}
+
+		if product.UUID != stockxtest.DefaultProductUUID || product.Styleid != stockxtest.DefaultProductStyleID {
+			t.Fatalf("unexpected product for %s: %+v", identifier, product)
+		}
+
+		if len(product.Variants) != 2 {
+			t.Fatalf("expected 2 variants for %s, got %d", identifier, len(product.Variants))
+		}
+	}
+
+	if len(server.RequestsTo("/")) == 0 || len(server.RequestsTo("/api/products/"+defaultVariantUUID)) != 1 {
+		t.Fatalf("unexpected requests: %+v", server.Requests())
+	}
+}
+
+func TestServerServesLookups(t *testing.T) {
+	server := stockxtest.NewServer()
+	defer server.Close()
+
+	client := newClient(t, server)
+
+	results, err := client.SearchProducts("dunk low", 10)
+	if err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+
+	if len(results) != 1 || results[0].ProductIdentifier != stockxtest.DefaultProductURLKey {
+		t.Fatalf("unexpected search results: %+v", results)
+	}
+
+	if query := server.RequestsTo("/api/browse")[0].Query.Get("_search"); query != "dunk+low" && query != "dunk low" {
+		t.Fatalf("unexpected search query %q", query)
+	}
+
+	product, err := client.GetProductByStyleID("dd1391 100")
+	if err != nil || product.UUID != stockxtest.DefaultProductUUID {
+		t.Fatalf("style id lookup failed: %v", err)
+	}
+
+	product, variant, err := client.GetProductByGTIN(defaultVariantGTIN)
+	if err != nil {
+		t.Fatalf("gtin lookup failed: %v", err)
+	}
+
+	if product.UUID != stockxtest.DefaultProductUUID || variant.UUID != defaultVariantUUID || variant.Size != "10" {
+		t.Fatalf("unexpected gtin lookup result: %s %+v", product.UUID, variant)
+	}
+}
+
+func TestServerCustomFixtures(t *testing.T) {
+	server := stockxtest.NewServer()
+	defer server.Close()
+
+	server.SetResponse("/api/products/"+stockxtest.DefaultProductURLKey+"/activity", http.StatusOK, []byte(`{
+		"Pagination": {"limit": 20, "page": 1, "total": 1},
+		"ProductActivity": [{"amount": 120, "localAmount": 110, "createdAt": "2023-10-01T12:00:00+00:00", "shoeSize": "10", "skuUuid": "`+defaultVariantUUID+`"}]
+	}`))
+
+	client := newClient(t, server)
+
+	sales, err := client.GetSales(stockxtest.DefaultProductURLKey, "", 1)
+	if err != nil {
+		t.Fatalf("failed to load sales: %v", err)
+	}
+
+	if len(sales) != 1 || sales[0].Amount != 110 || sales[0].Size != "10" {
+		t.Fatalf("unexpected sales: %+v", sales)
+	}
+
+	_, err = client.GetProduct("unknown-product")
+	if !errors.Is(err, go_stockx_client.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestServerFailures(t *testing.T) {
+	server := stockxtest.NewServer()
+	defer server.Close()
+
+	client := newClient(t, server)
+
+	server.Fail(stockxtest.Failure{Kind: stockxtest.FailureRateLimited, RetryAfter: "1"}.On("/api/products/"))
+
+	if _, err := client.GetProduct(stockxtest.DefaultProductURLKey); err == nil {
+		t.Fatalf("expected the rate limited request to fail")
+	}
+
+	if _, err := client.GetProduct(stockxtest.DefaultProductURLKey); err != nil {
+		t.Fatalf("expected the failure to be served once, got %v", err)
+	}
+
+	server.Fail(stockxtest.Failure{Kind: stockxtest.FailureCaptcha}.On("/api/products/"))
+
+	_, err := client.GetProduct(stockxtest.DefaultProductURLKey)
+	if !errors.Is(err, go_stockx_client.ErrChallenge) {
+		t.Fatalf("expected a challenge error, got %v", err)
+	}
+
+	server.Fail(stockxtest.Failure{Kind: stockxtest.FailureMalformedJSON}.On("/api/products/"))
+
+	if _, err = client.GetProduct(stockxtest.DefaultProductURLKey); err == nil {
+		t.Fatalf("expected malformed json to fail")
+	}
+
+	server.Fail(stockxtest.Failure{Kind: stockxtest.FailureSlow, Delay: 50 * time.Millisecond}.On("/api/products/"))
+
+	start := time.Now()
+	if _, err = client.GetProduct(stockxtest.DefaultProductURLKey); err != nil {
+		t.Fatalf("expected the slow request to succeed, got %v", err)
+	}
+
+	if time.Since(start) < 50*time.Millisecond {
+		t.Fatalf("expected the response to be delayed")
+	}
+
+	server.Reset()
+
+	if len(server.Requests()) != 0 {
+		t.Fatalf("expected Reset to forget all requests")
+	}
+}
+
+func TestServerHomepageStatus(t *testing.T) {
+	server := stockxtest.NewServer()
+	defer server.Close()
+
+	server.SetHomepageStatus(http.StatusServiceUnavailable)
+
+	if _, err := newClient(t, server).GetProduct(stockxtest.DefaultProductURLKey); err == nil {
+		t.Fatalf("expected the client initialization to fail")
+	}
+}
@@ -0,0 +1,128 @@
+package storage
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	go_stockx_client "github.com/bogdanfinn/go-stockx-client"
+)
+
+// FileStore appends one json encoded Snapshot per line. Queries scan the whole file, which is fine for
+// archiving and small datasets but the sql backends should be preferred for analytics.
+type FileStore struct {
+	sync.Mutex
+	path string
+}
+
+func OpenFile(path string) (*FileStore, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snapshot file: %w", err)
+	}
+
+	_ = file.Close()
+
+	return &FileStore{path: path}, nil
+}
+
+func (s *FileStore) Close() error {
+	return nil
+}
+
+func (s *FileStore) SaveProduct(product *go_stockx_client.ProductDetails, currency string, fetchedAt time.Time) error {
+	line, err := json.Marshal(Normalize(product, currency, fetchedAt))
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	file, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open snapshot file: %w", err)
+	}
+
+	defer file.Close()
+
+	_, err = file.Write(append(line, '\n'))
+	if err != nil {
+		return fmt.Errorf("failed to write snapshot file: %w", err)
+	}
+
+	return nil
+}
+
+func (s *FileStore) PriceHistory(query HistoryQuery) ([]VariantSnapshot, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	file, err := os.Open(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to open snapshot file: %w", err)
+	}
+
+	defer file.Close()
+
+	// products holds the latest saved row per product so a url key change applies to older snapshots too,
+	// the same way the sql backends join the current products row.
+	products := map[string]ProductRow{}
+	var markets []VariantSnapshot
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var snapshot Snapshot
+		err = json.Unmarshal(scanner.Bytes(), &snapshot)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode snapshot on line %d: %w", lineNumber, err)
+		}
+
+		products[snapshot.Product.UUID] = snapshot.Product
+		markets = append(markets, snapshot.Markets...)
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read snapshot file: %w", err)
+	}
+
+	var snapshots []VariantSnapshot
+	for _, market := range markets {
+		if query.matches(products[market.ProductUUID], market) {
+			snapshots = append(snapshots, market)
+		}
+	}
+
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].FetchedAt.Before(snapshots[j].FetchedAt)
+	})
+
+	return snapshots, nil
+}
+
+func (s *FileStore) LowestAskHistory(query HistoryQuery) ([]PricePoint, error) {
+	snapshots, err := s.PriceHistory(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return LowestAskCurve(snapshots), nil
+}
@@ -0,0 +1,94 @@
+package storage
+
+// Migrations are applied in order. Never edit a released migration, append a new one instead.
+var sqliteMigrations = []string{
+	`CREATE TABLE products (
+		uuid TEXT PRIMARY KEY,
+		id TEXT NOT NULL,
+		url_key TEXT NOT NULL,
+		title TEXT NOT NULL,
+		name TEXT NOT NULL,
+		brand TEXT NOT NULL,
+		colorway TEXT NOT NULL,
+		style_id TEXT NOT NULL,
+		release_date TEXT NOT NULL,
+		retail_price INTEGER NOT NULL,
+		size_locale TEXT NOT NULL,
+		size_title TEXT NOT NULL,
+		description TEXT NOT NULL,
+		image_url TEXT NOT NULL,
+		thumb_url TEXT NOT NULL,
+		updated_at INTEGER NOT NULL
+	);
+	CREATE INDEX products_url_key ON products (url_key);
+	CREATE TABLE variant_snapshots (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		product_uuid TEXT NOT NULL REFERENCES products (uuid),
+		variant_uuid TEXT NOT NULL,
+		size TEXT NOT NULL,
+		currency TEXT NOT NULL,
+		fetched_at INTEGER NOT NULL,
+		lowest_ask INTEGER NOT NULL,
+		highest_bid INTEGER NOT NULL,
+		annual_high INTEGER NOT NULL,
+		annual_low INTEGER NOT NULL,
+		last_sale INTEGER NOT NULL,
+		sales_last_72_hours INTEGER NOT NULL,
+		last_sale_date INTEGER NOT NULL,
+		lowest_ask_float REAL NOT NULL,
+		highest_bid_float REAL NOT NULL
+	);
+	CREATE INDEX variant_snapshots_history ON variant_snapshots (product_uuid, size, fetched_at);`,
+	`CREATE TABLE variants (
+		uuid TEXT PRIMARY KEY,
+		product_uuid TEXT NOT NULL REFERENCES products (uuid),
+		size TEXT NOT NULL
+	);
+	INSERT OR IGNORE INTO variants (uuid, product_uuid, size)
+		SELECT variant_uuid, product_uuid, size FROM variant_snapshots ORDER BY id DESC;`,
+}
+
+var postgresMigrations = []string{
+	`CREATE TABLE products (
+		uuid TEXT PRIMARY KEY,
+		id TEXT NOT NULL,
+		url_key TEXT NOT NULL,
+		title TEXT NOT NULL,
+		name TEXT NOT NULL,
+		brand TEXT NOT NULL,
+		colorway TEXT NOT NULL,
+		style_id TEXT NOT NULL,
+		release_date TEXT NOT NULL,
+		retail_price INTEGER NOT NULL,
+		size_locale TEXT NOT NULL,
+		size_title TEXT NOT NULL,
+		description TEXT NOT NULL,
+		image_url TEXT NOT NULL,
+		thumb_url TEXT NOT NULL,
+		updated_at BIGINT NOT NULL
+	);
+	CREATE INDEX products_url_key ON products (url_key);
+	CREATE TABLE variants (
+		uuid TEXT PRIMARY KEY,
+		product_uuid TEXT NOT NULL REFERENCES products (uuid),
+		size TEXT NOT NULL
+	);
+	CREATE TABLE variant_snapshots (
+		id BIGSERIAL PRIMARY KEY,
+		product_uuid TEXT NOT NULL REFERENCES products (uuid),
+		variant_uuid TEXT NOT NULL,
+		size TEXT NOT NULL,
+		currency TEXT NOT NULL,
+		fetched_at BIGINT NOT NULL,
+		lowest_ask INTEGER NOT NULL,
+		highest_bid INTEGER NOT NULL,
+		annual_high INTEGER NOT NULL,
+		annual_low INTEGER NOT NULL,
+		last_sale INTEGER NOT NULL,
+		sales_last_72_hours INTEGER NOT NULL,
+		last_sale_date BIGINT NOT NULL,
+		lowest_ask_float DOUBLE PRECISION NOT NULL,
+		highest_bid_float DOUBLE PRECISION NOT NULL
+	);
+	CREATE INDEX variant_snapshots_history ON variant_snapshots (product_uuid, size, fetched_at);`,
+}
@@ -0,0 +1,38 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+
+	_ "github.com/lib/pq"
+)
+
+type PostgresStore struct {
+	*sqlStore
+}
+
+// OpenPostgres connects to the database described by dsn and migrates it to the latest schema.
+func OpenPostgres(dsn string) (*PostgresStore, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open postgres database: %w", err)
+	}
+
+	store, err := NewPostgresStore(db)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return store, nil
+}
+
+// NewPostgresStore uses an already opened postgres database and migrates it to the latest schema.
+func NewPostgresStore(db *sql.DB) (*PostgresStore, error) {
+	store, err := newSqlStore(db, true, postgresMigrations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate postgres database: %w", err)
+	}
+
+	return &PostgresStore{sqlStore: store}, nil
+}
@@ -0,0 +1,187 @@
+package storage
+
+import (
+	"strings"
+	"time"
+
+	go_stockx_client "github.com/bogdanfinn/go-stockx-client"
+)
+
+// Snapshot is the normalized form of one GetProduct result which every SnapshotStore persists.
+type Snapshot struct {
+	Product  ProductRow        `json:"product"`
+	Variants []VariantRow      `json:"variants"`
+	Markets  []VariantSnapshot `json:"markets"`
+}
+
+type ProductRow struct {
+	UUID        string    `json:"uuid"`
+	ID          string    `json:"id"`
+	URLKey      string    `json:"urlKey"`
+	Title       string    `json:"title"`
+	Name        string    `json:"name"`
+	Brand       string    `json:"brand"`
+	Colorway    string    `json:"colorway"`
+	StyleID     string    `json:"styleId"`
+	ReleaseDate string    `json:"releaseDate"`
+	RetailPrice int       `json:"retailPrice"`
+	SizeLocale  string    `json:"sizeLocale"`
+	SizeTitle   string    `json:"sizeTitle"`
+	Description string    `json:"description"`
+	ImageUrl    string    `json:"imageUrl"`
+	ThumbUrl    string    `json:"thumbUrl"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+type VariantRow struct {
+	UUID        string `json:"uuid"`
+	ProductUUID string `json:"productUuid"`
+	Size        string `json:"size"`
+}
+
+// VariantSnapshot is the market row of a single variant at the time the product was fetched.
+type VariantSnapshot struct {
+	ProductUUID      string    `json:"productUuid"`
+	VariantUUID      string    `json:"variantUuid"`
+	Size             string    `json:"size"`
+	Currency         string    `json:"currency"`
+	FetchedAt        time.Time `json:"fetchedAt"`
+	Lowestask        int       `json:"lowestAsk"`
+	Highestbid       int       `json:"highestBid"`
+	Annualhigh       int       `json:"annualHigh"`
+	Annuallow        int       `json:"annualLow"`
+	Lastsale         int       `json:"lastSale"`
+	Saleslast72Hours int       `json:"salesLast72Hours"`
+	Lastsaledate     time.Time `json:"lastSaleDate"`
+	Lowestaskfloat   float64   `json:"lowestAskFloat"`
+	Highestbidfloat  float64   `json:"highestBidFloat"`
+}
+
+// Normalize splits product details into the product, variant and market rows stored by the backends.
+func Normalize(product *go_stockx_client.ProductDetails, currency string, fetchedAt time.Time) Snapshot {
+	fetchedAt = fetchedAt.UTC().Truncate(time.Millisecond)
+
+	snapshot := Snapshot{
+		Product: ProductRow{
+			UUID:        product.UUID,
+			ID:          product.ID,
+			URLKey:      product.ProductIdentifier,
+			Title:       product.Title,
+			Name:        product.Name,
+			Brand:       product.Brand,
+			Colorway:    product.Colorway,
+			StyleID:     product.Styleid,
+			ReleaseDate: product.Releasedate,
+			RetailPrice: product.Retailprice,
+			SizeLocale:  product.SizeLocale,
+			SizeTitle:   product.SizeTitle,
+			Description: product.Description,
+			ImageUrl:    product.Imageurl,
+			ThumbUrl:    product.Thumburl,
+			UpdatedAt:   fetchedAt,
+		},
+	}
+
+	for _, variant := range product.Variants {
+		snapshot.Variants = append(snapshot.Variants, VariantRow{
+			UUID:        variant.UUID,
+			ProductUUID: product.UUID,
+			Size:        variant.Size,
+		})
+
+		snapshot.Markets = append(snapshot.Markets, VariantSnapshot{
+			ProductUUID:      product.UUID,
+			VariantUUID:      variant.UUID,
+			Size:             variant.Size,
+			Currency:         strings.ToUpper(currency),
+			FetchedAt:        fetchedAt,
+			Lowestask:        variant.Lowestask,
+			Highestbid:       variant.Highestbid,
+			Annualhigh:       variant.Annualhigh,
+			Annuallow:        variant.Annuallow,
+			Lastsale:         variant.Lastsale,
+			Saleslast72Hours: variant.Saleslast72Hours,
+			Lastsaledate:     variant.Lastsaledate.UTC().Truncate(time.Millisecond),
+			Lowestaskfloat:   variant.Lowestaskfloat,
+			Highestbidfloat:  variant.Highestbidfloat,
+		})
+	}
+
+	return snapshot
+}
+
+// HistoryQuery selects stored variant snapshots. Product matches either the product uuid or its current url key,
+// the one of the most recently saved snapshot, so renamed products keep their history. An empty Size returns all sizes
+// and zero From / To values leave the time range open.
+type HistoryQuery struct {
+	Product  string
+	Size     string
+	Currency string
+	From     time.Time
+	To       time.Time
+}
+
+func (q HistoryQuery) matches(product ProductRow, market VariantSnapshot) bool {
+	if q.Product != product.UUID && q.Product != product.URLKey {
+		return false
+	}
+
+	if q.Size != "" && q.Size != market.Size {
+		return false
+	}
+
+	if q.Currency != "" && !strings.EqualFold(q.Currency, market.Currency) {
+		return false
+	}
+
+	if !q.From.IsZero() && market.FetchedAt.Before(q.From) {
+		return false
+	}
+
+	if !q.To.IsZero() && market.FetchedAt.After(q.To) {
+		return false
+	}
+
+	return true
+}
+
+type PricePoint struct {
+	Time  time.Time `json:"time"`
+	Size  string    `json:"size"`
+	Price int       `json:"price"`
+}
+
+// LowestAskCurve reduces snapshots to their lowest ask over time. Snapshots without any ask are skipped.
+func LowestAskCurve(snapshots []VariantSnapshot) []PricePoint {
+	var points []PricePoint
+
+	for _, snapshot := range snapshots {
+		if snapshot.Lowestask == 0 {
+			continue
+		}
+
+		points = append(points, PricePoint{
+			Time:  snapshot.FetchedAt,
+			Size:  snapshot.Size,
+			Price: snapshot.Lowestask,
+		})
+	}
+
+	return points
+}
+
+func toUnixMilli(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+func fromUnixMilli(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, ms*int64(time.Millisecond)).UTC()
+}
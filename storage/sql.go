@@ -0,0 +1,231 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	go_stockx_client "github.com/bogdanfinn/go-stockx-client"
+)
+
+// sqlStore holds the queries shared by the sqlite and postgres backends. Queries are written with "?"
+// placeholders and rebound for the dialect before execution.
+type sqlStore struct {
+	db         *sql.DB
+	numbered   bool
+	migrations []string
+}
+
+func newSqlStore(db *sql.DB, numbered bool, migrations []string) (*sqlStore, error) {
+	store := &sqlStore{
+		db:         db,
+		numbered:   numbered,
+		migrations: migrations,
+	}
+
+	err := store.migrate()
+	if err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+func (s *sqlStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *sqlStore) rebind(query string) string {
+	if !s.numbered {
+		return query
+	}
+
+	var builder strings.Builder
+	n := 0
+
+	for _, char := range query {
+		if char != '?' {
+			builder.WriteRune(char)
+			continue
+		}
+
+		n++
+		builder.WriteString("$")
+		builder.WriteString(strconv.Itoa(n))
+	}
+
+	return builder.String()
+}
+
+// SaveProduct stores the product metadata and one market snapshot per variant taken at fetchedAt.
+func (s *sqlStore) SaveProduct(product *go_stockx_client.ProductDetails, currency string, fetchedAt time.Time) error {
+	snapshot := Normalize(product, currency, fetchedAt)
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+
+	row := snapshot.Product
+	_, err = tx.Exec(s.rebind(`INSERT INTO products (uuid, id, url_key, title, name, brand, colorway, style_id, release_date, retail_price, size_locale, size_title, description, image_url, thumb_url, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (uuid) DO UPDATE SET
+			id = excluded.id, url_key = excluded.url_key, title = excluded.title, name = excluded.name, brand = excluded.brand,
+			colorway = excluded.colorway, style_id = excluded.style_id, release_date = excluded.release_date,
+			retail_price = excluded.retail_price, size_locale = excluded.size_locale, size_title = excluded.size_title,
+			description = excluded.description, image_url = excluded.image_url, thumb_url = excluded.thumb_url,
+			updated_at = excluded.updated_at`),
+		row.UUID, row.ID, row.URLKey, row.Title, row.Name, row.Brand, row.Colorway, row.StyleID, row.ReleaseDate,
+		row.RetailPrice, row.SizeLocale, row.SizeTitle, row.Description, row.ImageUrl, row.ThumbUrl, toUnixMilli(row.UpdatedAt),
+	)
+	if err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("failed to store product %s: %w", row.UUID, err)
+	}
+
+	for _, variant := range snapshot.Variants {
+		_, err = tx.Exec(s.rebind(`INSERT INTO variants (uuid, product_uuid, size) VALUES (?, ?, ?)
+			ON CONFLICT (uuid) DO UPDATE SET product_uuid = excluded.product_uuid, size = excluded.size`),
+			variant.UUID, variant.ProductUUID, variant.Size,
+		)
+		if err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("failed to store variant %s: %w", variant.UUID, err)
+		}
+	}
+
+	for _, market := range snapshot.Markets {
+		_, err = tx.Exec(s.rebind(`INSERT INTO variant_snapshots (product_uuid, variant_uuid, size, currency, fetched_at, lowest_ask, highest_bid, annual_high, annual_low, last_sale, sales_last_72_hours, last_sale_date, lowest_ask_float, highest_bid_float)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+			market.ProductUUID, market.VariantUUID, market.Size, market.Currency, toUnixMilli(market.FetchedAt),
+			market.Lowestask, market.Highestbid, market.Annualhigh, market.Annuallow, market.Lastsale,
+			market.Saleslast72Hours, toUnixMilli(market.Lastsaledate), market.Lowestaskfloat, market.Highestbidfloat,
+		)
+		if err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("failed to store variant snapshot %s: %w", market.VariantUUID, err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("failed to commit product snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// PriceHistory returns the stored variant snapshots matching the query ordered by fetch time.
+func (s *sqlStore) PriceHistory(query HistoryQuery) ([]VariantSnapshot, error) {
+	conditions := []string{"(p.uuid = ? OR p.url_key = ?)"}
+	args := []interface{}{query.Product, query.Product}
+
+	if query.Size != "" {
+		conditions = append(conditions, "v.size = ?")
+		args = append(args, query.Size)
+	}
+
+	if query.Currency != "" {
+		conditions = append(conditions, "v.currency = ?")
+		args = append(args, strings.ToUpper(query.Currency))
+	}
+
+	if !query.From.IsZero() {
+		conditions = append(conditions, "v.fetched_at >= ?")
+		args = append(args, toUnixMilli(query.From))
+	}
+
+	if !query.To.IsZero() {
+		conditions = append(conditions, "v.fetched_at <= ?")
+		args = append(args, toUnixMilli(query.To))
+	}
+
+	rows, err := s.db.Query(s.rebind(`SELECT v.product_uuid, v.variant_uuid, v.size, v.currency, v.fetched_at, v.lowest_ask, v.highest_bid, v.annual_high, v.annual_low, v.last_sale, v.sales_last_72_hours, v.last_sale_date, v.lowest_ask_float, v.highest_bid_float
+		FROM variant_snapshots v JOIN products p ON p.uuid = v.product_uuid
+		WHERE `+strings.Join(conditions, " AND ")+`
+		ORDER BY v.fetched_at, v.id`), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query price history: %w", err)
+	}
+
+	defer rows.Close()
+
+	var snapshots []VariantSnapshot
+
+	for rows.Next() {
+		var snapshot VariantSnapshot
+		var fetchedAt, lastSaleDate int64
+
+		err = rows.Scan(&snapshot.ProductUUID, &snapshot.VariantUUID, &snapshot.Size, &snapshot.Currency, &fetchedAt,
+			&snapshot.Lowestask, &snapshot.Highestbid, &snapshot.Annualhigh, &snapshot.Annuallow, &snapshot.Lastsale,
+			&snapshot.Saleslast72Hours, &lastSaleDate, &snapshot.Lowestaskfloat, &snapshot.Highestbidfloat,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read price history row: %w", err)
+		}
+
+		snapshot.FetchedAt = fromUnixMilli(fetchedAt)
+		snapshot.Lastsaledate = fromUnixMilli(lastSaleDate)
+
+		snapshots = append(snapshots, snapshot)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read price history: %w", err)
+	}
+
+	return snapshots, nil
+}
+
+// LowestAskHistory is a shortcut for LowestAskCurve(PriceHistory(query)).
+func (s *sqlStore) LowestAskHistory(query HistoryQuery) ([]PricePoint, error) {
+	snapshots, err := s.PriceHistory(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return LowestAskCurve(snapshots), nil
+}
+
+func (s *sqlStore) migrate() error {
+	_, err := s.db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER PRIMARY KEY)`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema migrations table: %w", err)
+	}
+
+	var current int
+	err = s.db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&current)
+	if err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	for i := current; i < len(s.migrations); i++ {
+		version := i + 1
+
+		tx, err := s.db.Begin()
+		if err != nil {
+			return fmt.Errorf("failed to start migration %d: %w", version, err)
+		}
+
+		_, err = tx.Exec(s.migrations[i])
+		if err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("failed to apply migration %d: %w", version, err)
+		}
+
+		_, err = tx.Exec(s.rebind(`INSERT INTO schema_migrations (version) VALUES (?)`), version)
+		if err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("failed to record migration %d: %w", version, err)
+		}
+
+		err = tx.Commit()
+		if err != nil {
+			return fmt.Errorf("failed to commit migration %d: %w", version, err)
+		}
+	}
+
+	return nil
+}
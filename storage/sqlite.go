@@ -0,0 +1,38 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+type SQLiteStore struct {
+	*sqlStore
+}
+
+// OpenSQLite opens (or creates) the database file at path and migrates it to the latest schema.
+func OpenSQLite(path string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite3", fmt.Sprintf("file:%s?_foreign_keys=on&_busy_timeout=5000", path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite database: %w", err)
+	}
+
+	store, err := NewSQLiteStore(db)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return store, nil
+}
+
+// NewSQLiteStore uses an already opened sqlite database and migrates it to the latest schema.
+func NewSQLiteStore(db *sql.DB) (*SQLiteStore, error) {
+	store, err := newSqlStore(db, false, sqliteMigrations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate sqlite database: %w", err)
+	}
+
+	return &SQLiteStore{sqlStore: store}, nil
+}
@@ -0,0 +1,158 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	go_stockx_client "github.com/bogdanfinn/go-stockx-client"
+)
+
+var testFetchedAt = time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func testProduct(urlKey string, lowestAsk int) *go_stockx_client.ProductDetails {
+	return &go_stockx_client.ProductDetails{
+		ID:                "product-id",
+		UUID:              "5e6a1e57-1c7d-435a-82bd-5666a13560fe",
+		Brand:             "Nike",
+		Colorway:          "White/Black",
+		Title:             "Nike Dunk Low Retro White Black",
+		Styleid:           "DD1391-100",
+		ProductIdentifier: urlKey,
+		Retailprice:       110,
+		Variants: []go_stockx_client.ProductDetailsVariant{
+			{
+				UUID:         "1a0ea6c7-5a8b-4a3c-9c8e-5b7a1b0d6f01",
+				Size:         "9",
+				Lowestask:    lowestAsk,
+				Highestbid:   lowestAsk - 20,
+				Lastsaledate: testFetchedAt.Add(-time.Hour),
+			},
+			{
+				UUID:       "2b1fb7d8-6b9c-4b4d-8d9f-6c8b2c1e7a02",
+				Size:       "10",
+				Lowestask:  0,
+				Highestbid: lowestAsk - 30,
+			},
+		},
+	}
+}
+
+// testSnapshotStore runs the behaviour every SnapshotStore has to share against store.
+func testSnapshotStore(t *testing.T, store SnapshotStore) {
+	t.Helper()
+
+	product := testProduct("nike-dunk-low-retro-white-black-2021", 150)
+
+	if err := store.SaveProduct(product, "eur", testFetchedAt); err != nil {
+		t.Fatalf("failed to save product: %v", err)
+	}
+
+	if err := store.SaveProduct(testProduct("nike-dunk-low-retro-white-black-2021", 140), "EUR", testFetchedAt.Add(time.Hour)); err != nil {
+		t.Fatalf("failed to save product: %v", err)
+	}
+
+	t.Run("by uuid", func(t *testing.T) {
+		snapshots, err := store.PriceHistory(HistoryQuery{Product: product.UUID})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(snapshots) != 4 {
+			t.Fatalf("expected 4 snapshots, got %d", len(snapshots))
+		}
+
+		first := snapshots[0]
+		if first.Currency != "EUR" || !first.FetchedAt.Equal(testFetchedAt) || first.ProductUUID != product.UUID {
+			t.Fatalf("unexpected snapshot: %+v", first)
+		}
+
+		if !snapshots[3].FetchedAt.Equal(testFetchedAt.Add(time.Hour)) {
+			t.Fatalf("expected snapshots ordered by fetch time, got %+v", snapshots)
+		}
+	})
+
+	t.Run("by url key, size and time range", func(t *testing.T) {
+		snapshots, err := store.PriceHistory(HistoryQuery{
+			Product:  product.ProductIdentifier,
+			Size:     "9",
+			Currency: "eur",
+			From:     testFetchedAt.Add(30 * time.Minute),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(snapshots) != 1 || snapshots[0].Lowestask != 140 || snapshots[0].Size != "9" {
+			t.Fatalf("unexpected snapshots: %+v", snapshots)
+		}
+
+		if !snapshots[0].Lastsaledate.Equal(testFetchedAt.Add(-time.Hour)) {
+			t.Fatalf("expected last sale date to round trip, got %s", snapshots[0].Lastsaledate)
+		}
+	})
+
+	t.Run("other currency", func(t *testing.T) {
+		snapshots, err := store.PriceHistory(HistoryQuery{Product: product.UUID, Currency: "USD"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(snapshots) != 0 {
+			t.Fatalf("expected no snapshots, got %d", len(snapshots))
+		}
+	})
+
+	t.Run("lowest ask curve", func(t *testing.T) {
+		points, err := store.LowestAskHistory(HistoryQuery{Product: product.UUID})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(points) != 2 || points[0].Price != 150 || points[1].Price != 140 {
+			t.Fatalf("unexpected points: %+v", points)
+		}
+	})
+
+	t.Run("url key change", func(t *testing.T) {
+		renamed := testProduct("nike-dunk-low-panda", 130)
+
+		if err := store.SaveProduct(renamed, "EUR", testFetchedAt.Add(2*time.Hour)); err != nil {
+			t.Fatalf("failed to save product: %v", err)
+		}
+
+		snapshots, err := store.PriceHistory(HistoryQuery{Product: "nike-dunk-low-panda"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(snapshots) != 6 {
+			t.Fatalf("expected the renamed product to keep its history, got %d snapshots", len(snapshots))
+		}
+
+		snapshots, err = store.PriceHistory(HistoryQuery{Product: "nike-dunk-low-retro-white-black-2021"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(snapshots) != 0 {
+			t.Fatalf("expected the old url key to no longer match, got %d snapshots", len(snapshots))
+		}
+	})
+}
+
+func TestFileStore(t *testing.T) {
+	store, err := Open(Config{Backend: BackendFile, DSN: filepath.Join(t.TempDir(), "snapshots.jsonl")})
+	if err != nil {
+		t.Fatalf("failed to open file store: %v", err)
+	}
+	defer store.Close()
+
+	testSnapshotStore(t, store)
+}
+
+func TestOpenUnsupportedBackend(t *testing.T) {
+	if _, err := Open(Config{Backend: "mongo"}); err == nil {
+		t.Fatalf("expected an error")
+	}
+}
@@ -0,0 +1,59 @@
+package go_stockx_client
+
+import (
+	"context"
+	"net/url"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/bogdanfinn/go-stockx-client"
+
+// WithContext returns a client bound to ctx which shares the session (cookies, identity, proxy) with c.
+// Spans of the returned client are children of the span in ctx and its requests are canceled with ctx.
+func (c *client) WithContext(ctx context.Context) Client {
+	bound := *c
+	bound.ctx = ctx
+
+	return &bound
+}
+
+// startSpan starts a span as child of the context of the client and returns a client bound to the span.
+func (c *client) startSpan(name string, kind trace.SpanKind, attributes ...attribute.KeyValue) (*client, trace.Span) {
+	attributes = append(attributes,
+		attribute.String("stockx.currency", c.currency),
+		attribute.String("stockx.market", c.locale),
+	)
+
+	ctx, span := c.tracer.Start(c.ctx, name, trace.WithSpanKind(kind), trace.WithAttributes(attributes...))
+
+	traced := *c
+	traced.ctx = ctx
+
+	return &traced, span
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// redactedProxy returns the proxy url without credentials, or an empty string if no proxy is used.
+func redactedProxy(proxyUrl string) string {
+	if proxyUrl == "" {
+		return ""
+	}
+
+	parsedUrl, err := url.Parse(proxyUrl)
+	if err != nil || parsedUrl.Host == "" {
+		return "[unparsable]"
+	}
+
+	return parsedUrl.Scheme + "://" + parsedUrl.Host
+}
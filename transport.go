@@ -0,0 +1,212 @@
+package go_stockx_client
+
+import (
+	"fmt"
+	nethttp "net/http"
+	netcookiejar "net/http/cookiejar"
+	"net/url"
+	"sync"
+	"time"
+
+	http "github.com/bogdanfinn/fhttp"
+	"github.com/bogdanfinn/fhttp/cookiejar"
+	tls_client "github.com/bogdanfinn/tls-client"
+	"github.com/bogdanfinn/tls-client/profiles"
+)
+
+// Transport sends the requests of the client. The default transport is a tls-client http client which
+// mimics the tls fingerprint of a browser, use WithTransport to replace it (for example with a net/http
+// based transport in tests).
+type Transport interface {
+	Do(req *http.Request) (*http.Response, error)
+	SetProxy(proxyUrl string) error
+	GetProxy() string
+}
+
+// IdentityTransport is implemented by transports whose tls fingerprint follows the browser identity of the client.
+type IdentityTransport interface {
+	Transport
+	SetIdentity(identity BrowserIdentity) error
+}
+
+var _ IdentityTransport = (*tlsClientTransport)(nil)
+
+type tlsClientTransport struct {
+	sync.RWMutex
+	logger     Logger
+	httpClient tls_client.HttpClient
+}
+
+func NewTLSClientTransport(logger Logger) (Transport, error) {
+	return newTLSClientTransport(logger, DefaultIdentity.ClientProfile)
+}
+
+func newTLSClientTransport(logger Logger, clientProfile profiles.ClientProfile) (*tlsClientTransport, error) {
+	httpClient, err := newTLSHttpClient(logger, clientProfile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tlsClientTransport{
+		logger:     logger,
+		httpClient: httpClient,
+	}, nil
+}
+
+func newTLSHttpClient(logger Logger, clientProfile profiles.ClientProfile) (tls_client.HttpClient, error) {
+	jar, _ := cookiejar.New(nil)
+
+	httpClientOptions := []tls_client.HttpClientOption{
+		tls_client.WithTimeoutSeconds(30),
+		tls_client.WithClientProfile(clientProfile),
+		tls_client.WithCookieJar(jar),
+		// tls_client.WithNotFollowRedirects(),
+	}
+
+	httpClient, err := tls_client.NewHttpClient(logger, httpClientOptions...)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct http client: %w", err)
+	}
+
+	return httpClient, nil
+}
+
+// SetIdentity replaces the underlying http client with one using the tls profile of identity. The proxy is kept,
+// cookies are not, a new identity starts a new session.
+func (t *tlsClientTransport) SetIdentity(identity BrowserIdentity) error {
+	httpClient, err := newTLSHttpClient(t.logger, identity.ClientProfile)
+	if err != nil {
+		return err
+	}
+
+	t.Lock()
+	defer t.Unlock()
+
+	if proxyUrl := t.httpClient.GetProxy(); proxyUrl != "" {
+		err = httpClient.SetProxy(proxyUrl)
+		if err != nil {
+			return fmt.Errorf("failed to set proxy: %w", err)
+		}
+	}
+
+	t.httpClient.CloseIdleConnections()
+	t.httpClient = httpClient
+
+	return nil
+}
+
+func (t *tlsClientTransport) Do(req *http.Request) (*http.Response, error) {
+	t.RLock()
+	httpClient := t.httpClient
+	t.RUnlock()
+
+	return httpClient.Do(req)
+}
+
+func (t *tlsClientTransport) SetProxy(proxyUrl string) error {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.httpClient.SetProxy(proxyUrl)
+}
+
+func (t *tlsClientTransport) GetProxy() string {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.httpClient.GetProxy()
+}
+
+type netHttpTransport struct {
+	sync.RWMutex
+	client   *nethttp.Client
+	proxyUrl string
+}
+
+// NewNetHttpTransport returns a transport based on the standard library. It does not mimic any browser
+// fingerprint and is meant for local testing, for example against an httptest server.
+func NewNetHttpTransport(timeout time.Duration) Transport {
+	jar, _ := netcookiejar.New(nil)
+
+	t := &netHttpTransport{}
+	t.client = &nethttp.Client{
+		Timeout: timeout,
+		Jar:     jar,
+		Transport: &nethttp.Transport{
+			Proxy: t.proxy,
+		},
+	}
+
+	return t
+}
+
+func (t *netHttpTransport) proxy(_ *nethttp.Request) (*url.URL, error) {
+	t.RLock()
+	defer t.RUnlock()
+
+	if t.proxyUrl == "" {
+		return nil, nil
+	}
+
+	return url.Parse(t.proxyUrl)
+}
+
+func (t *netHttpTransport) SetProxy(proxyUrl string) error {
+	if proxyUrl != "" {
+		if _, err := url.Parse(proxyUrl); err != nil {
+			return fmt.Errorf("failed to parse proxy url: %w", err)
+		}
+	}
+
+	t.Lock()
+	t.proxyUrl = proxyUrl
+	t.Unlock()
+
+	t.client.CloseIdleConnections()
+
+	return nil
+}
+
+func (t *netHttpTransport) GetProxy() string {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.proxyUrl
+}
+
+func (t *netHttpTransport) Do(req *http.Request) (*http.Response, error) {
+	netReq, err := nethttp.NewRequestWithContext(req.Context(), req.Method, req.URL.String(), req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, values := range req.Header {
+		if key == http.HeaderOrderKey || key == http.PHeaderOrderKey {
+			continue
+		}
+
+		// header keys are canonicalized, otherwise net/http adds its own user-agent next to ours
+		for _, value := range values {
+			netReq.Header.Add(key, value)
+		}
+	}
+
+	netResp, err := t.client.Do(netReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		Status:        netResp.Status,
+		StatusCode:    netResp.StatusCode,
+		Proto:         netResp.Proto,
+		ProtoMajor:    netResp.ProtoMajor,
+		ProtoMinor:    netResp.ProtoMinor,
+		Header:        http.Header(netResp.Header),
+		Body:          netResp.Body,
+		ContentLength: netResp.ContentLength,
+		Uncompressed:  netResp.Uncompressed,
+		Request:       req,
+	}, nil
+}
@@ -37,18 +37,62 @@ type ProductDetails struct {
 	Lowestaskfloat    float64                 `json:"lowestAskFloat"`
 	Highestbidfloat   float64                 `json:"highestBidFloat"`
 	Variants          []ProductDetailsVariant `json:"variants"`
+
+	ReleaseTime          time.Time                   `json:"releaseTime"`
+	Gender               string                      `json:"gender"`
+	Condition            string                      `json:"condition"`
+	Countryofmanufacture string                      `json:"countryOfManufacture"`
+	Primarycategory      string                      `json:"primaryCategory"`
+	Secondarycategory    string                      `json:"secondaryCategory"`
+	Productcategory      string                      `json:"productCategory"`
+	Tickersymbol         string                      `json:"tickerSymbol"`
+	Year                 int                         `json:"year"`
+	Breadcrumbs          []ProductDetailsBreadcrumb  `json:"breadcrumbs"`
+	Shipping             ProductDetailsShipping      `json:"shipping"`
+	Images360            []string                    `json:"images360"`
+	Gallery              []string                    `json:"gallery"`
+	Enhancedimage        ProductDetailsEnhancedImage `json:"enhancedImage"`
+	Market               MarketData                  `json:"market"`
+}
+
+type ProductDetailsBreadcrumb struct {
+	Level int    `json:"level"`
+	Name  string `json:"name"`
+	URL   string `json:"url"`
+}
+
+type ProductDetailsShipping struct {
+	Totaldaystoship         int  `json:"totalDaysToShip"`
+	Hasadditionaldaystoship bool `json:"hasAdditionalDaysToShip"`
+	Deliverydayslowerbound  int  `json:"deliveryDaysLowerBound"`
+	Deliverydaysupperbound  int  `json:"deliveryDaysUpperBound"`
+}
+
+type ProductDetailsEnhancedImage struct {
+	Productuuid string `json:"productUuid"`
+	Imagekey    string `json:"imageKey"`
+	Imagecount  int    `json:"imageCount"`
 }
 
 type ProductDetailsVariant struct {
-	UUID             string    `json:"UUID"`
-	Size             string    `json:"size"`
-	Lowestask        int       `json:"lowestAsk"`
-	Highestbid       int       `json:"highestBid"`
-	Annualhigh       int       `json:"annualHigh"`
-	Annuallow        int       `json:"annualLow"`
-	Lastsale         int       `json:"lastSale"`
-	Saleslast72Hours int       `json:"salesLast72Hours"`
-	Lastsaledate     time.Time `json:"lastSaleDate"`
-	Lowestaskfloat   float64   `json:"lowestAskFloat"`
-	Highestbidfloat  float64   `json:"highestBidFloat"`
+	UUID             string     `json:"UUID"`
+	Size             string     `json:"size"`
+	GTINs            []string   `json:"gtins"`
+	Lowestask        int        `json:"lowestAsk"`
+	Highestbid       int        `json:"highestBid"`
+	Annualhigh       int        `json:"annualHigh"`
+	Annuallow        int        `json:"annualLow"`
+	Lastsale         int        `json:"lastSale"`
+	Saleslast72Hours int        `json:"salesLast72Hours"`
+	Lastsaledate     time.Time  `json:"lastSaleDate"`
+	Lowestaskfloat   float64    `json:"lowestAskFloat"`
+	Highestbidfloat  float64    `json:"highestBidFloat"`
+	Market           MarketData `json:"market"`
+}
+
+type Sale struct {
+	Amount      float64   `json:"amount"`
+	Size        string    `json:"size"`
+	Time        time.Time `json:"time"`
+	VariantUUID string    `json:"variantUuid"`
 }